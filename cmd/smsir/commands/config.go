@@ -2,8 +2,14 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"strconv"
 
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
 	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/output"
+	"github.com/SaneiyanReza/smsir-cli/internal/secret"
 	"github.com/spf13/cobra"
 )
 
@@ -16,49 +22,217 @@ var configCmd = &cobra.Command{
 
 // configSetCmd represents the config set command
 var configSetCmd = &cobra.Command{
-	Use:   "set",
-	Short: "Set API Key and line number",
-	Long:  `Set API Key and line number for SMS.ir connection`,
+	Use:   "set [key value]",
+	Short: "Set configuration values",
+	Long: `Set a configuration key of the active profile, or the API Key and line number with flags.
+
+Examples:
+  smsir config set line_number 30001234
+  smsir config set dashboard.refresh 30
+  smsir config set optout.keywords "STOP,لغو"
+  smsir config set --api-key KEY --line 30001234
+
+Run 'smsir config list' to see every key.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 && len(args) != 2 {
+			return i18n.Errorf("expected a key and a value")
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiKey, _ := cmd.Flags().GetString("api-key")
 		lineNumber, _ := cmd.Flags().GetString("line")
 
-		if apiKey == "" {
-			return fmt.Errorf("api key is required")
+		if len(args) == 0 && apiKey == "" && lineNumber == "" {
+			return i18n.Errorf("specify a key and a value, or --api-key and --line")
+		}
+
+		profileCfg, err := loadProfileForEdit()
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 2 {
+			if err := profileCfg.Set(args[0], args[1]); err != nil {
+				return err
+			}
+		}
+		if apiKey != "" {
+			profileCfg.APIKey = apiKey
+		}
+		if lineNumber != "" {
+			profileCfg.LineNumber = lineNumber
+		}
+
+		if err := profileCfg.ValidateValues(); err != nil {
+			return i18n.Errorf("invalid configuration: %w", err)
+		}
+
+		if err := profileCfg.SaveConfig(); err != nil {
+			return i18n.Errorf("error saving configuration: %w", err)
+		}
+
+		notice("✅ Configuration saved successfully (profile %q)\n", profileCfg.Profile)
+		return nil
+	},
+}
+
+// configGetCmd represents the config get command
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a configuration value",
+	Long: `Print the effective value of a configuration key, after the project file
+and environment variables were applied. Secrets are masked unless --reveal is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reveal, _ := cmd.Flags().GetBool("reveal")
+
+		key, err := config.LookupKey(args[0])
+		if err != nil {
+			return err
+		}
+		value, err := cfg.Get(key.Name)
+		if err != nil {
+			return err
 		}
-		if lineNumber == "" {
-			return fmt.Errorf("line number is required")
+		if key.Secret && !reveal {
+			value = maskString(value)
 		}
 
-		// Load or create config
-		var err error
-		cfg, err = config.LoadConfig()
+		fmt.Println(value)
+		return nil
+	},
+}
+
+// configUnsetCmd represents the config unset command
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Restore the default value of a configuration key",
+	Long:  `Restore the default value of a configuration key in the active profile`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profileCfg, err := loadProfileForEdit()
 		if err != nil {
-			return fmt.Errorf("error loading configuration: %w", err)
+			return err
 		}
 
-		cfg.APIKey = apiKey
-		cfg.LineNumber = lineNumber
+		if err := profileCfg.Unset(args[0]); err != nil {
+			return err
+		}
 
-		if err := cfg.SaveConfig(); err != nil {
-			return fmt.Errorf("error saving configuration: %w", err)
+		if err := profileCfg.SaveConfig(); err != nil {
+			return i18n.Errorf("error saving configuration: %w", err)
 		}
 
-		fmt.Println("✅ Configuration saved successfully")
+		notice("✅ %s restored to its default (profile %q)\n", args[0], profileCfg.Profile)
 		return nil
 	},
 }
 
+// configListCmd represents the config list command
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all configuration keys and values",
+	Long:  `List every configuration key with its effective value; secrets are masked`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keys := config.Keys()
+
+		results := make([]configKeyResult, 0, len(keys))
+		rows := make([][]string, 0, len(keys))
+		for _, key := range keys {
+			value, err := cfg.Get(key.Name)
+			if err != nil {
+				return err
+			}
+			if key.Secret && value != "" {
+				value = maskString(value)
+			}
+			results = append(results, configKeyResult{
+				Key:         key.Name,
+				Value:       value,
+				Source:      cfg.Source(key.Name),
+				Description: key.Description,
+			})
+			rows = append(rows, []string{key.Name, value, cfg.Source(key.Name), key.Description})
+		}
+
+		return out.Render(output.Result{
+			Data:    results,
+			Columns: []string{"KEY", "VALUE", "SOURCE", "DESCRIPTION"},
+			Rows:    rows,
+			Text: func(w io.Writer) {
+				i18n.Fprintf(w, "⚙️  Configuration (profile %q):\n", cfg.Profile)
+				for _, r := range results {
+					i18n.Fprintf(w, "  %-24s %s\n", r.Key, r.Value)
+				}
+			},
+		})
+	},
+}
+
 // configShowCmd represents the config show command
 var configShowCmd = &cobra.Command{
 	Use:   "show",
 	Short: "Show current configuration",
 	Long:  `Show current configuration including API Key and line number`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Printf("API Key: %s\n", maskString(cfg.APIKey))
-		fmt.Printf("Line Number: %s\n", cfg.LineNumber)
-		fmt.Printf("Base URL: %s\n", cfg.BaseURL)
-		return nil
+		apiKey := maskString(cfg.APIKey)
+
+		configFile, err := config.FilePath()
+		if err != nil {
+			return i18n.Errorf("error finding configuration file: %w", err)
+		}
+		var projectFile string
+		if project, err := config.LoadProject(); err == nil && project != nil {
+			projectFile = project.Path
+		}
+
+		sources := map[string]string{
+			"profile":     cfg.Source("profile"),
+			"api_key":     cfg.Source("api_key"),
+			"line_number": cfg.Source("line_number"),
+			"base_url":    cfg.Source("base_url"),
+		}
+		if cfg.APIKey == "" {
+			sources["api_key"] = "not set"
+		}
+
+		return out.Render(output.Result{
+			Data: configResult{
+				Profile:     cfg.Profile,
+				APIKey:      apiKey,
+				LineNumber:  cfg.LineNumber,
+				BaseURL:     cfg.BaseURL,
+				ConfigFile:  configFile,
+				ProjectFile: projectFile,
+				ReadOnly:    config.ReadOnly(),
+				Sources:     sources,
+			},
+			Columns: []string{"KEY", "VALUE", "SOURCE"},
+			Rows: [][]string{
+				{"profile", cfg.Profile, sources["profile"]},
+				{"api_key", apiKey, sources["api_key"]},
+				{"line_number", cfg.LineNumber, sources["line_number"]},
+				{"base_url", cfg.BaseURL, sources["base_url"]},
+				{"config_file", configFile, ""},
+				{"project_file", projectFile, ""},
+				{"read_only", strconv.FormatBool(config.ReadOnly()), ""},
+			},
+			Text: func(w io.Writer) {
+				i18n.Fprintf(w, "Profile: %s (%s)\n", cfg.Profile, sources["profile"])
+				i18n.Fprintf(w, "API Key: %s (%s)\n", apiKey, sources["api_key"])
+				i18n.Fprintf(w, "Line Number: %s (%s)\n", cfg.LineNumber, sources["line_number"])
+				i18n.Fprintf(w, "Base URL: %s (%s)\n", cfg.BaseURL, sources["base_url"])
+				i18n.Fprintf(w, "Config File: %s\n", configFile)
+				if projectFile != "" {
+					i18n.Fprintf(w, "Project File: %s\n", projectFile)
+				}
+				if config.ReadOnly() {
+					fmt.Fprintln(w, i18n.T("Mode: read-only (nothing is written to disk)"))
+				}
+			},
+			Values: []string{apiKey, cfg.LineNumber, cfg.BaseURL},
+		})
 	},
 }
 
@@ -66,13 +240,106 @@ var configShowCmd = &cobra.Command{
 var configValidateCmd = &cobra.Command{
 	Use:   "validate",
 	Short: "Validate configuration",
-	Long:  `Validate current configuration`,
+	Long: `Validate current configuration.
+
+With --online the configuration is also checked against the live account:
+the base URL must be reachable, the API key accepted and the line number one
+of the account's lines. The local clock is compared with the server's.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := cfg.Validate(); err != nil {
-			return fmt.Errorf("invalid configuration: %w", err)
+		online, _ := cmd.Flags().GetBool("online")
+
+		validateErr := cfg.Validate()
+		if !online {
+			if validateErr != nil {
+				return i18n.Errorf("invalid configuration: %w", validateErr)
+			}
+			fmt.Println(i18n.T("✅ Configuration is valid"))
+			return nil
+		}
+
+		// The offline result is one row, so the online checks still show what works
+		results := []api.CheckResult{{Name: "configuration", OK: true, Detail: i18n.T("valid")}}
+		if validateErr != nil {
+			results[0] = api.CheckResult{Name: "configuration", Detail: validateErr.Error()}
 		}
+		client := api.NewClient(cfg)
+		results = append(results, client.CheckConfig()...)
 
-		fmt.Println("✅ Configuration is valid")
+		rows := make([][]string, len(results))
+		for i, r := range results {
+			rows[i] = []string{r.Name, checkStatus(r), r.Detail}
+		}
+		if err := out.Render(output.Result{
+			Data:    results,
+			Columns: []string{"CHECK", "STATUS", "DETAIL"},
+			Rows:    rows,
+			Text: func(w io.Writer) {
+				for _, r := range results {
+					icon := "✅"
+					if r.Warning {
+						icon = "⚠️ "
+					} else if !r.OK {
+						icon = "❌"
+					}
+					i18n.Fprintf(w, "%s %s: %s\n", icon, i18n.T(r.Name), r.Detail)
+				}
+			},
+		}); err != nil {
+			return err
+		}
+
+		if !api.ChecksPassed(results) {
+			return i18n.Errorf("configuration does not work with the account")
+		}
+		return nil
+	},
+}
+
+// checkStatus returns the status column of an online check
+func checkStatus(r api.CheckResult) string {
+	switch {
+	case r.OK:
+		return "pass"
+	case r.Warning:
+		return "warn"
+	default:
+		return "fail"
+	}
+}
+
+// configBackendCmd represents the config backend command
+var configBackendCmd = &cobra.Command{
+	Use:   "backend [keyring|file|env]",
+	Short: "Show or change where API keys are stored",
+	Long: `Show or change the secret backend that stores API keys.
+
+  keyring  OS keyring (Keychain, Credential Manager, Secret Service)
+  file     file encrypted with a passphrase (asked for or read from SMSIR_PASSPHRASE)
+  env      nothing is stored; the key is read from SMSIR_API_KEY
+
+Changing the backend moves every stored key to the new one.`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{secret.BackendKeyring, secret.BackendFile, secret.BackendEnv},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := config.LoadFile()
+		if err != nil {
+			return i18n.Errorf("error loading configuration: %w", err)
+		}
+
+		if len(args) == 0 {
+			fmt.Println(f.SecretBackendName())
+			return nil
+		}
+
+		if err := f.SetSecretBackend(args[0]); err != nil {
+			return i18n.Errorf("error changing secret backend: %w", err)
+		}
+
+		if args[0] == secret.BackendEnv {
+			notice("✅ API keys are no longer stored; set SMSIR_API_KEY before running smsir\n")
+			return nil
+		}
+		notice("✅ API keys are now stored in %s\n", args[0])
 		return nil
 	},
 }
@@ -81,13 +348,50 @@ func init() {
 	// Note: configCmd is added to rootCmd in root.go setupCommands() to control order
 	// Only add sub-commands here
 	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configEditCmd)
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configDoctorCmd)
+	configCmd.AddCommand(configBackendCmd)
 
 	configSetCmd.Flags().String("api-key", "", "API Key from SMS.ir panel")
 	configSetCmd.Flags().String("line", "", "Line number")
-	configSetCmd.MarkFlagRequired("api-key")
-	configSetCmd.MarkFlagRequired("line")
+
+	configGetCmd.Flags().Bool("reveal", false, "print secrets unmasked")
+	configValidateCmd.Flags().Bool("online", false, "also check the key, line, reachability and clock against the live account")
+}
+
+// configResult is the structured output of the config show command
+type configResult struct {
+	Profile     string            `json:"profile"`
+	APIKey      string            `json:"api_key"`
+	LineNumber  string            `json:"line_number"`
+	BaseURL     string            `json:"base_url"`
+	ConfigFile  string            `json:"config_file"`
+	ProjectFile string            `json:"project_file,omitempty"`
+	ReadOnly    bool              `json:"read_only"`
+	Sources     map[string]string `json:"sources"`
+}
+
+// configKeyResult is a single entry of the config list command
+type configKeyResult struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Source      string `json:"source"`
+	Description string `json:"description"`
+}
+
+// loadProfileForEdit loads the active profile as stored in the config file,
+// without project or environment values, creating it if it does not exist yet
+func loadProfileForEdit() (*config.Config, error) {
+	profileCfg, err := config.LoadProfileForEdit()
+	if err != nil {
+		return nil, i18n.Errorf("error loading configuration: %w", err)
+	}
+	return profileCfg, nil
 }
 
 // maskString masks sensitive information
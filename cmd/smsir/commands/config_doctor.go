@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/output"
+	"github.com/SaneiyanReza/smsir-cli/internal/secret"
+	"github.com/spf13/cobra"
+)
+
+// configDoctorCmd represents the config doctor command
+var configDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check configuration files and secret storage",
+	Long: `Check the configuration directory for problems:
+
+• files or directories readable by other users
+• API keys still stored in plaintext in config.json
+• a secret backend that cannot be reached or does not hold the API key
+
+Use --fix to tighten permissions and move plaintext keys into the secret backend.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fix, _ := cmd.Flags().GetBool("fix")
+
+		var checks []doctorCheck
+		add := func(name string, ok bool, detail string) {
+			checks = append(checks, doctorCheck{Check: name, OK: ok, Detail: detail})
+		}
+
+		dir, err := config.Dir()
+		if err != nil {
+			return i18n.Errorf("error finding configuration directory: %w", err)
+		}
+
+		// Permissions of the directory and every file in it
+		paths := []string{dir}
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			// Nothing has been written yet, e.g. in read-only mode
+			paths = nil
+		} else if err != nil {
+			return i18n.Errorf("error reading configuration directory: %w", err)
+		}
+		for _, e := range entries {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+		// A file chosen with --config or SMSIR_CONFIG may live elsewhere
+		if configFile, err := config.FilePath(); err == nil && filepath.Dir(configFile) != dir {
+			if _, err := os.Stat(configFile); err == nil {
+				paths = append(paths, configFile)
+			}
+		}
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil {
+				add(path, false, err.Error())
+				continue
+			}
+			if config.IsPrivate(info.Mode()) {
+				add(path, true, i18n.Sprintf("permissions %04o", info.Mode().Perm()))
+				continue
+			}
+			if fix && !config.ReadOnly() {
+				want := config.PrivateMode(info.IsDir())
+				if err := os.Chmod(path, want); err != nil {
+					add(path, false, i18n.Sprintf("failed to fix permissions: %v", err))
+				} else {
+					add(path, true, i18n.Sprintf("permissions fixed %04o → %04o", info.Mode().Perm(), want))
+				}
+				continue
+			}
+			add(path, false, i18n.Sprintf("permissions %04o allow access by other users", info.Mode().Perm()))
+		}
+
+		f, err := config.LoadFile()
+		if err != nil {
+			return i18n.Errorf("error loading configuration: %w", err)
+		}
+
+		// Secret backend
+		backend := f.SecretBackendName()
+		switch backend {
+		case secret.BackendKeyring:
+			if (secret.KeyringBackend{}).Available() {
+				add("secret backend", true, "OS keyring")
+			} else {
+				add("secret backend", false, "OS keyring is not available; run 'smsir config backend file'")
+			}
+		case secret.BackendFile:
+			add("secret backend", true, "encrypted file")
+		case secret.BackendEnv:
+			add("secret backend", true, "environment only (SMSIR_API_KEY)")
+		default:
+			add("secret backend", false, i18n.Sprintf("unknown backend %q", backend))
+		}
+
+		// Plaintext API keys left over from older versions
+		if f.HasPlaintextKeys() {
+			if fix && !config.ReadOnly() {
+				if err := f.Save(); err != nil {
+					add("plaintext keys", false, i18n.Sprintf("failed to move keys: %v", err))
+				} else {
+					add("plaintext keys", true, i18n.Sprintf("keys moved to %s", backend))
+				}
+			} else {
+				add("plaintext keys", false, "config.json holds API keys in plaintext")
+			}
+		} else {
+			add("plaintext keys", true, "none")
+		}
+
+		// A project file may not make settings that only the user file can
+		projectErr := func() error { _, err := config.LoadProject(); return err }()
+		if projectErr != nil {
+			add("project file", false, projectErr.Error())
+		}
+
+		// The active profile's key must be readable; with an unusable project
+		// file only the user file's profile can be checked
+		loadActive := config.LoadConfig
+		if projectErr != nil {
+			loadActive = func() (*config.Config, error) { return config.LoadProfile(f.ActiveProfile()) }
+		}
+		if _, err := loadActive(); err != nil {
+			add("api key", false, err.Error())
+		} else {
+			add("api key", true, i18n.Sprintf("readable for profile %q", f.ActiveProfile()))
+		}
+
+		problems := 0
+		rows := make([][]string, len(checks))
+		for i, c := range checks {
+			status := "ok"
+			if !c.OK {
+				status = "problem"
+				problems++
+			}
+			rows[i] = []string{c.Check, status, c.Detail}
+		}
+
+		if err := out.Render(output.Result{
+			Data:    checks,
+			Columns: []string{"CHECK", "STATUS", "DETAIL"},
+			Rows:    rows,
+			Text: func(w io.Writer) {
+				for _, c := range checks {
+					icon := "✅"
+					if !c.OK {
+						icon = "⚠️ "
+					}
+					i18n.Fprintf(w, "%s %s: %s\n", icon, i18n.T(c.Check), c.Detail)
+				}
+			},
+		}); err != nil {
+			return err
+		}
+
+		if problems > 0 {
+			if !fix {
+				notice("\n💡 Run 'smsir config doctor --fix' to repair what can be fixed automatically\n")
+			}
+			return i18n.Errorf("%d problem(s) found", problems)
+		}
+		return nil
+	},
+}
+
+func init() {
+	configDoctorCmd.Flags().Bool("fix", false, "tighten permissions and move plaintext keys into the secret backend")
+}
+
+// doctorCheck is a single result of the config doctor command
+type doctorCheck struct {
+	Check  string `json:"check"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail"`
+}
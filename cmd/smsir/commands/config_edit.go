@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// configEditCmd represents the config edit command
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the active profile in your editor",
+	Long: `Open the active profile in $VISUAL or $EDITOR as JSON. The result is validated
+before it is saved; on errors you can edit again or cancel.
+
+The API key is not shown; add an "api_key" entry to replace it.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profileCfg, err := loadProfileForEdit()
+		if err != nil {
+			return err
+		}
+
+		// Secrets never go to the temporary file
+		editable := *profileCfg
+		editable.APIKey = ""
+		editable.APIKeyRef = ""
+		content, err := json.MarshalIndent(&editable, "", "  ")
+		if err != nil {
+			return i18n.Errorf("error preparing configuration: %w", err)
+		}
+
+		tmp, err := os.CreateTemp("", "smsir-config-*.json")
+		if err != nil {
+			return i18n.Errorf("error creating temporary file: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+		tmp.Close()
+
+		for {
+			if err := os.WriteFile(tmp.Name(), content, 0600); err != nil {
+				return i18n.Errorf("error writing temporary file: %w", err)
+			}
+			if err := runEditor(tmp.Name()); err != nil {
+				return err
+			}
+
+			content, err = os.ReadFile(tmp.Name())
+			if err != nil {
+				return i18n.Errorf("error reading edited configuration: %w", err)
+			}
+
+			edited, err := parseEditedConfig(content, profileCfg)
+			if err == nil {
+				if err := edited.SaveConfig(); err != nil {
+					return i18n.Errorf("error saving configuration: %w", err)
+				}
+				notice("✅ Configuration saved successfully (profile %q)\n", edited.Profile)
+				return nil
+			}
+
+			i18n.Fprintf(os.Stderr, "❌ %v\n", err)
+			if !confirm("Edit again? [Y/n] ") {
+				return i18n.Errorf("configuration not changed")
+			}
+		}
+	},
+}
+
+// parseEditedConfig decodes an edited profile and validates it. Keys that were
+// removed fall back to their defaults; the API key is kept unless replaced.
+func parseEditedConfig(content []byte, original *config.Config) (*config.Config, error) {
+	edited := config.DefaultConfig()
+
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(edited); err != nil {
+		return nil, i18n.Errorf("invalid JSON: %w", err)
+	}
+
+	if edited.APIKey == "" {
+		edited.APIKey = original.APIKey
+		edited.APIKeyRef = original.APIKeyRef
+	}
+	edited.Profile = original.Profile
+
+	if err := edited.ValidateValues(); err != nil {
+		return nil, i18n.Errorf("invalid configuration: %w", err)
+	}
+	return edited, nil
+}
+
+// runEditor opens a file in the user's editor and waits for it to exit
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	// The editor may carry arguments, e.g. "code --wait"
+	parts := strings.Fields(editor)
+	c := exec.Command(parts[0], append(parts[1:], path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return i18n.Errorf("error running editor %q: %w", editor, err)
+	}
+	return nil
+}
+
+// confirm asks a yes/no question on stderr; the answer defaults to yes
+func confirm(prompt string) bool {
+	fmt.Fprint(os.Stderr, i18n.T(prompt))
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "" || answer == "y" || answer == "yes" || answer == i18n.T("yes")
+}
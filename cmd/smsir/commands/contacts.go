@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/contacts"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// contactsCmd represents the contacts command
+var contactsCmd = &cobra.Command{
+	Use:   "contacts",
+	Short: "Local contacts and groups",
+	Long: `Manage the local address book used by the send wizard's contacts picker.
+
+Contacts can belong to groups, so a whole group can be picked as recipients at once.`,
+}
+
+// contactsListCmd represents the contacts list command
+var contactsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show contacts",
+	Long:  `Show all contacts, or only the members of one group`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		group, _ := cmd.Flags().GetString("group")
+
+		book, err := contacts.Load()
+		if err != nil {
+			return i18n.Errorf("error loading contacts: %w", err)
+		}
+
+		list := book.Contacts()
+		if group != "" {
+			list = book.Group(group)
+		}
+
+		var rows [][]string
+		var values []string
+		for _, c := range list {
+			rows = append(rows, []string{c.Name, c.Mobile, strings.Join(c.Groups, ",")})
+			values = append(values, c.Mobile)
+		}
+
+		return out.Render(output.Result{
+			Data:    list,
+			Columns: []string{"NAME", "MOBILE", "GROUPS"},
+			Rows:    rows,
+			Text: func(w io.Writer) {
+				if len(list) == 0 {
+					fmt.Fprintln(w, i18n.T("👤 No contacts found"))
+					return
+				}
+
+				fmt.Fprintln(w, i18n.T("👤 Contacts:"))
+				for i, c := range list {
+					i18n.Fprintf(w, "  %d. %s  %s", i+1, c.Name, c.Mobile)
+					if len(c.Groups) > 0 {
+						fmt.Fprintf(w, "  [%s]", strings.Join(c.Groups, ", "))
+					}
+					fmt.Fprintln(w)
+				}
+			},
+			Values: values,
+		})
+	},
+}
+
+// contactsAddCmd represents the contacts add command
+var contactsAddCmd = &cobra.Command{
+	Use:   "add <name> <mobile>",
+	Short: "Add or update a contact",
+	Long: `Add a contact, or update the name and groups of an existing number.
+
+Examples:
+  smsir contacts add "Sara Ahmadi" 09121234567
+  smsir contacts add Ali 09351234567 --group customers --group vip`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		groups, _ := cmd.Flags().GetStringSlice("group")
+
+		book, err := contacts.Load()
+		if err != nil {
+			return i18n.Errorf("error loading contacts: %w", err)
+		}
+
+		c, err := book.Set(args[0], args[1], groups)
+		if err != nil {
+			return err
+		}
+
+		if err := book.Save(); err != nil {
+			return i18n.Errorf("error saving contacts: %w", err)
+		}
+
+		notice("✅ Contact %s (%s) saved\n", c.Name, c.Mobile)
+		return nil
+	},
+}
+
+// contactsRemoveCmd represents the contacts remove command
+var contactsRemoveCmd = &cobra.Command{
+	Use:     "remove <name|mobile>...",
+	Aliases: []string{"rm"},
+	Short:   "Remove contacts",
+	Long:    `Remove contacts by name or mobile number`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		book, err := contacts.Load()
+		if err != nil {
+			return i18n.Errorf("error loading contacts: %w", err)
+		}
+
+		removed := 0
+		for _, arg := range args {
+			removed += book.Remove(arg)
+		}
+
+		if err := book.Save(); err != nil {
+			return i18n.Errorf("error saving contacts: %w", err)
+		}
+
+		notice("✅ %d contact(s) removed\n", removed)
+		return nil
+	},
+}
+
+func init() {
+	contactsCmd.AddCommand(contactsListCmd)
+	contactsCmd.AddCommand(contactsAddCmd)
+	contactsCmd.AddCommand(contactsRemoveCmd)
+
+	contactsListCmd.Flags().String("group", "", "Only show members of this group")
+	contactsAddCmd.Flags().StringSlice("group", nil, "Group to put the contact in (repeatable)")
+}
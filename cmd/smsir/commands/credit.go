@@ -1,9 +1,12 @@
 package commands
 
 import (
-	"fmt"
+	"io"
+	"strconv"
 
 	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -17,15 +20,32 @@ var creditCmd = &cobra.Command{
 
 		resp, err := client.GetCredit()
 		if err != nil {
-			return fmt.Errorf("error getting credit: %w", err)
+			return i18n.Errorf("error getting credit: %w", err)
 		}
 
 		if !resp.IsSuccess() {
-			return fmt.Errorf("API error: %s", resp.GetStatusMessage())
+			return i18n.Errorf("API error: %s", resp.GetStatusMessage())
 		}
 
 		credit := float64(resp.Data)
-		fmt.Printf("💰 Current Credit: %.2f SMS\n", credit)
-		return nil
+		return out.Render(output.Result{
+			Data:    creditResult{Credit: credit},
+			Columns: []string{"CREDIT"},
+			Rows:    [][]string{{formatFloat(credit)}},
+			Text: func(w io.Writer) {
+				i18n.Fprintf(w, "💰 Current Credit: %.2f SMS\n", credit)
+			},
+			Values: []string{formatFloat(credit)},
+		})
 	},
 }
+
+// creditResult is the structured output of the credit command
+type creditResult struct {
+	Credit float64 `json:"credit"`
+}
+
+// formatFloat formats a number without trailing zeros for script-friendly output
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
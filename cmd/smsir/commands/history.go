@@ -0,0 +1,455 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/history"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Sent message history",
+	Long:  `Browse the local history of every message sent from the CLI, the interactive UI and batch jobs`,
+}
+
+// historyListCmd represents the history list command
+var historyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List sent messages",
+	Long:  `List sent messages, newest first, optionally filtered by date, recipient, text or tag`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := historyFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		return listHistory(cmd, filter)
+	},
+}
+
+// historySearchCmd represents the history search command
+var historySearchCmd = &cobra.Command{
+	Use:   "search <text>",
+	Short: "Search sent messages by text",
+	Long:  `Search sent messages whose text contains the given phrase (case-insensitive)`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := historyFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		filter.Text = args[0]
+		return listHistory(cmd, filter)
+	},
+}
+
+// historyShowCmd represents the history show command
+var historyShowCmd = &cobra.Command{
+	Use:   "show <id|packId>",
+	Short: "Show a single sent message",
+	Long:  `Show all stored details of a sent message by history ID or pack ID`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := history.Open()
+		if err != nil {
+			return i18n.Errorf("error opening history: %w", err)
+		}
+		defer store.Close()
+
+		var record *history.Record
+		if id, err := strconv.ParseUint(args[0], 10, 64); err == nil {
+			record, err = store.Get(id)
+			if err != nil {
+				return err
+			}
+		} else {
+			record, err = store.FindByPackID(args[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		return out.Render(output.Result{
+			Data:    record,
+			Columns: historyColumns,
+			Rows:    [][]string{historyRow(record)},
+			Text: func(w io.Writer) {
+				printHistoryRecord(w, record)
+			},
+			Values: []string{record.PackID},
+		})
+	},
+}
+
+// historySyncCmd represents the history sync command
+var historySyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Fetch delivery status for pending messages",
+	Long: `Look up the delivery reports of messages that have not reached a final state yet
+and store the outcome in the local history, so delivery rates can be shown offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sinceStr, _ := cmd.Flags().GetString("since")
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+		if batchSize < 1 {
+			return i18n.Errorf("batch size must be at least 1")
+		}
+
+		since, err := parseTimeFlag(sinceStr)
+		if err != nil {
+			return i18n.Errorf("invalid --since: %w", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return i18n.Errorf("invalid configuration: %w", err)
+		}
+
+		// The database is not kept open during API calls so that concurrent
+		// sends can still record their history
+		store, err := history.Open()
+		if err != nil {
+			return i18n.Errorf("error opening history: %w", err)
+		}
+		records, err := store.List(history.Filter{Since: since})
+		store.Close()
+		if err != nil {
+			return i18n.Errorf("error reading history: %w", err)
+		}
+
+		var pending []*history.Record
+		for i := range records {
+			if records[i].IsPending() {
+				pending = append(pending, &records[i])
+			}
+		}
+
+		if len(pending) == 0 {
+			notice("✅ No pending messages to sync\n")
+			return nil
+		}
+
+		client := api.NewClient(cfg)
+		synced, failed := 0, 0
+		for start := 0; start < len(pending); start += batchSize {
+			end := start + batchSize
+			if end > len(pending) {
+				end = len(pending)
+			}
+
+			var batch []*history.Record
+			for _, r := range pending[start:end] {
+				if err := history.Reconcile(client, r); err != nil {
+					i18n.Fprintf(os.Stderr, "⚠️  Pack %s: %v\n", r.PackID, err)
+					failed++
+					continue
+				}
+				batch = append(batch, r)
+			}
+
+			if len(batch) > 0 {
+				store, err := history.Open()
+				if err != nil {
+					return i18n.Errorf("error opening history: %w", err)
+				}
+				err = store.Update(batch...)
+				store.Close()
+				if err != nil {
+					return i18n.Errorf("error saving history: %w", err)
+				}
+				synced += len(batch)
+			}
+
+			notice("🔄 Synced %d/%d\n", end, len(pending))
+		}
+
+		notice("✅ %d record(s) synced, %d failed\n", synced, failed)
+		return nil
+	},
+}
+
+// historyStatsCmd represents the history stats command
+var historyStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show delivery rates from local history",
+	Long: `Show delivery rates grouped by campaign (tag), recipient or line, using the
+delivery states stored by "smsir history sync".`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		by, _ := cmd.Flags().GetString("by")
+		switch by {
+		case "campaign", "tag", "recipient", "line":
+		default:
+			return i18n.Errorf("unknown grouping: %s (use campaign, recipient or line)", by)
+		}
+		filter, err := historyFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		store, err := history.Open()
+		if err != nil {
+			return i18n.Errorf("error opening history: %w", err)
+		}
+		defer store.Close()
+
+		records, err := store.List(filter)
+		if err != nil {
+			return i18n.Errorf("error reading history: %w", err)
+		}
+
+		groups := make(map[string]*api.DeliverySummary)
+		var keys []string
+		add := func(key string, state api.DeliveryState) {
+			s, ok := groups[key]
+			if !ok {
+				s = &api.DeliverySummary{}
+				groups[key] = s
+				keys = append(keys, key)
+			}
+			s.Count(state)
+		}
+
+		// Messages are counted, as in history show and the pack report
+		for _, r := range records {
+			for _, m := range r.Messages() {
+				switch by {
+				case "campaign", "tag":
+					if len(r.Tags) == 0 {
+						add("(untagged)", m.State)
+					}
+					for _, tag := range r.Tags {
+						add(tag, m.State)
+					}
+				case "recipient":
+					if m.Mobile == "" {
+						add("(unknown)", m.State)
+					} else {
+						add(m.Mobile, m.State)
+					}
+				case "line":
+					add(strconv.FormatInt(r.LineNumber, 10), m.State)
+				}
+			}
+		}
+		sort.Strings(keys)
+
+		stats := []historyStat{}
+		var rows [][]string
+		for _, key := range keys {
+			summary := *groups[key]
+			rate := deliveryRate(summary)
+			stats = append(stats, historyStat{Key: key, DeliverySummary: summary, DeliveryRate: rate})
+			rows = append(rows, []string{
+				key,
+				strconv.Itoa(summary.Total),
+				strconv.Itoa(summary.Delivered),
+				strconv.Itoa(summary.Failed),
+				strconv.Itoa(summary.Pending),
+				fmt.Sprintf("%.1f%%", rate*100),
+			})
+		}
+
+		result := output.Result{
+			Data:    stats,
+			Columns: []string{strings.ToUpper(by), "SENT", "DELIVERED", "FAILED", "PENDING", "RATE"},
+			Rows:    rows,
+			Values:  keys,
+		}
+		if len(keys) == 0 {
+			result.Text = func(w io.Writer) {
+				fmt.Fprintln(w, i18n.T("📭 No messages found"))
+			}
+		}
+		return out.Render(result)
+	},
+}
+
+func init() {
+	historyCmd.AddCommand(historyListCmd)
+	historyCmd.AddCommand(historySearchCmd)
+	historyCmd.AddCommand(historyShowCmd)
+	historyCmd.AddCommand(historySyncCmd)
+	historyCmd.AddCommand(historyStatsCmd)
+
+	historySyncCmd.Flags().String("since", "30d", "Only sync messages sent after this date or age")
+	historySyncCmd.Flags().Int("batch-size", 20, "Number of records reconciled before each save")
+	historyStatsCmd.Flags().String("by", "campaign", "Group by campaign (tag), recipient or line")
+
+	for _, c := range []*cobra.Command{historyListCmd, historySearchCmd, historyStatsCmd} {
+		c.Flags().String("since", "", "Only messages after this date (2006-01-02) or age (e.g. 24h, 7d)")
+		c.Flags().String("until", "", "Only messages before this date (2006-01-02) or age (e.g. 24h, 7d)")
+		c.Flags().String("to", "", "Only messages sent to this mobile number")
+		c.Flags().String("text", "", "Only messages containing this text")
+		c.Flags().String("tag", "", "Only messages with this tag")
+		limit := 50
+		if c == historyStatsCmd {
+			limit = 0
+		}
+		c.Flags().Int("limit", limit, "Maximum number of messages to include (0 for all)")
+	}
+}
+
+// historyFilterFromFlags builds a history filter from the list/search flags
+func historyFilterFromFlags(cmd *cobra.Command) (history.Filter, error) {
+	var filter history.Filter
+	var err error
+
+	since, _ := cmd.Flags().GetString("since")
+	if filter.Since, err = parseTimeFlag(since); err != nil {
+		return filter, i18n.Errorf("invalid --since: %w", err)
+	}
+	until, _ := cmd.Flags().GetString("until")
+	if filter.Until, err = parseTimeFlag(until); err != nil {
+		return filter, i18n.Errorf("invalid --until: %w", err)
+	}
+
+	filter.Recipient, _ = cmd.Flags().GetString("to")
+	filter.Text, _ = cmd.Flags().GetString("text")
+	filter.Tag, _ = cmd.Flags().GetString("tag")
+	filter.Limit, _ = cmd.Flags().GetInt("limit")
+	return filter, nil
+}
+
+// listHistory prints the records matching the filter
+func listHistory(cmd *cobra.Command, filter history.Filter) error {
+	store, err := history.Open()
+	if err != nil {
+		return i18n.Errorf("error opening history: %w", err)
+	}
+	defer store.Close()
+
+	records, err := store.List(filter)
+	if err != nil {
+		return i18n.Errorf("error reading history: %w", err)
+	}
+
+	if records == nil {
+		records = []history.Record{}
+	}
+
+	var rows [][]string
+	var ids []string
+	for i := range records {
+		rows = append(rows, historyRow(&records[i]))
+		ids = append(ids, strconv.FormatUint(records[i].ID, 10))
+	}
+
+	result := output.Result{
+		Data:    records,
+		Columns: historyColumns,
+		Rows:    rows,
+		Values:  ids,
+	}
+	if len(records) == 0 {
+		result.Text = func(w io.Writer) {
+			fmt.Fprintln(w, i18n.T("📭 No messages found"))
+		}
+	}
+	return out.Render(result)
+}
+
+// historyColumns are the table columns of a history record
+var historyColumns = []string{"ID", "TIME", "ORIGIN", "LINE", "RECIPIENTS", "DELIVERED", "COST", "PACK ID", "MESSAGE"}
+
+// historyRow returns the table row of a history record
+func historyRow(r *history.Record) []string {
+	summary := r.Summary()
+	return []string{
+		strconv.FormatUint(r.ID, 10),
+		r.Timestamp.Format("2006-01-02 15:04"),
+		string(r.Origin),
+		strconv.FormatInt(r.LineNumber, 10),
+		strconv.Itoa(len(r.Recipients)),
+		fmt.Sprintf("%d/%d", summary.Delivered, summary.Total),
+		fmt.Sprintf("%.2f", r.Cost),
+		r.PackID,
+		truncate(r.MessageText, 40),
+	}
+}
+
+// printHistoryRecord writes all details of a history record
+func printHistoryRecord(w io.Writer, record *history.Record) {
+	i18n.Fprintf(w, "🆔 ID: %d\n", record.ID)
+	i18n.Fprintf(w, "🕒 Time: %s\n", record.Timestamp.Format("2006-01-02 15:04:05"))
+	if !record.SendAt.IsZero() {
+		i18n.Fprintf(w, "🕒 Scheduled for %s\n", record.SendAt.Format("2006-01-02 15:04"))
+	}
+	i18n.Fprintf(w, "👤 Profile: %s\n", record.Profile)
+	i18n.Fprintf(w, "🧭 Origin: %s\n", record.Origin)
+	i18n.Fprintf(w, "📞 Line Number: %d\n", record.LineNumber)
+	i18n.Fprintf(w, "📦 Pack ID: %s\n", record.PackID)
+	i18n.Fprintf(w, "💰 Cost: %.2f SMS\n", record.Cost)
+	if len(record.Tags) > 0 {
+		i18n.Fprintf(w, "🏷️  Tags: %s\n", strings.Join(record.Tags, ", "))
+	}
+	i18n.Fprintf(w, "📱 Recipients (%d): %s\n", len(record.Recipients), strings.Join(record.Recipients, ", "))
+	i18n.Fprintf(w, "🔢 Message IDs: %v\n", record.MessageIDs)
+	if len(record.Deliveries) > 0 {
+		summary := record.Summary()
+		i18n.Fprintf(w, "📬 Delivery (synced %s): %d delivered, %d failed, %d pending\n",
+			record.SyncedAt.Format("2006-01-02 15:04"), summary.Delivered, summary.Failed, summary.Pending)
+		for _, d := range record.Deliveries {
+			i18n.Fprintf(w, "  %d  %s  %s\n", d.MessageID, d.Mobile, i18n.T(d.State.String()))
+		}
+	}
+	i18n.Fprintf(w, "💬 Message:\n%s\n", record.MessageText)
+}
+
+// historyStat is the structured output of one group in the history stats command
+type historyStat struct {
+	Key string `json:"key"`
+	api.DeliverySummary
+	DeliveryRate float64 `json:"deliveryRate"`
+}
+
+// deliveryRate returns the fraction of delivered messages
+func deliveryRate(s api.DeliverySummary) float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Delivered) / float64(s.Total)
+}
+
+// parseTimeFlag parses an absolute date or a relative age such as 24h or 7d
+func parseTimeFlag(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04", time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err == nil {
+			return time.Now().AddDate(0, 0, -days), nil
+		}
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, i18n.Errorf("expected a date like 2006-01-02 or an age like 24h or 7d")
+	}
+	return time.Now().Add(-d), nil
+}
+
+// truncate shortens s to at most n runes and keeps it on a single line
+func truncate(s string, n int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// setupLanguage selects the language and digits before cobra runs, since
+// the help and usage output is printed without running PersistentPreRunE.
+// The flags are read from the arguments here and declared on RootCmd so
+// cobra accepts them.
+func setupLanguage(args []string) error {
+	flags := pflag.NewFlagSet("lang", pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	flags.Usage = func() {}
+	lang := flags.String("lang", "", "")
+	digits := flags.String("digits", "", "")
+	// Errors about other flags are reported by cobra
+	_ = flags.Parse(args)
+
+	selected, err := i18n.Detect(*lang)
+	if err != nil {
+		return err
+	}
+	i18n.SetLang(selected)
+
+	if *digits == "" {
+		*digits = os.Getenv("SMSIR_DIGITS")
+	}
+	persianDigits, err := i18n.ParseDigits(*digits)
+	if err != nil {
+		return err
+	}
+	i18n.SetPersianDigits(persianDigits)
+
+	if selected != i18n.English {
+		localizeCommand(RootCmd, make(map[*pflag.Flag]bool))
+	}
+	return nil
+}
+
+// localizeCommand translates the descriptions, flag usages and help
+// template of cmd and its subcommands
+func localizeCommand(cmd *cobra.Command, seen map[*pflag.Flag]bool) {
+	cmd.InitDefaultHelpCmd()
+	cmd.InitDefaultHelpFlag()
+
+	cmd.Short = i18n.T(cmd.Short)
+	cmd.Long = i18n.T(cmd.Long)
+	if help := cmd.Flags().Lookup("help"); help != nil && !seen[help] {
+		help.Usage = i18n.Sprintf("help for %s", cmd.Name())
+		seen[help] = true
+	}
+
+	translate := func(f *pflag.Flag) {
+		if !seen[f] {
+			f.Usage = i18n.T(f.Usage)
+			seen[f] = true
+		}
+	}
+	cmd.Flags().VisitAll(translate)
+	cmd.PersistentFlags().VisitAll(translate)
+
+	if !cmd.HasParent() {
+		cmd.SetUsageTemplate(localizeTemplate(cmd.UsageTemplate()))
+		cmd.SetErrPrefix(i18n.T("Error:"))
+	}
+
+	for _, sub := range cmd.Commands() {
+		localizeCommand(sub, seen)
+	}
+}
+
+// localizeTemplate translates the headings of cobra's usage template
+func localizeTemplate(template string) string {
+	var pairs []string
+	for _, heading := range []string{
+		"Usage:", "Aliases:", "Examples:", "Available Commands:", "Additional Commands:",
+		"Global Flags:", "Flags:", "Additional help topics:",
+	} {
+		pairs = append(pairs, heading, i18n.T(heading))
+	}
+	pairs = append(pairs, `Use "{{.CommandPath}} [command] --help" for more information about a command.`,
+		i18n.Sprintf("Use \"%s [command] --help\" for more information about a command.", "{{.CommandPath}}"))
+	return strings.NewReplacer(pairs...).Replace(template)
+}
@@ -2,8 +2,14 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 
 	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -17,25 +23,243 @@ var linesCmd = &cobra.Command{
 
 		resp, err := client.GetLines()
 		if err != nil {
-			return fmt.Errorf("error getting lines: %w", err)
+			return i18n.Errorf("error getting lines: %w", err)
 		}
 
 		if !resp.IsSuccess() {
-			return fmt.Errorf("API error: %s", resp.GetStatusMessage())
+			return i18n.Errorf("API error: %s", resp.GetStatusMessage())
 		}
 
 		lines := []int64(resp.Data)
 
-		if len(lines) == 0 {
-			fmt.Println("📞 No lines found")
-			return nil
+		results := make([]lineResult, 0, len(lines))
+		var rows [][]string
+		var values []string
+		onAccount := make(map[string]bool)
+		for _, line := range lines {
+			number := strconv.FormatInt(line, 10)
+			onAccount[number] = true
+			aliases := cfg.LineAliases(number)
+			results = append(results, lineResult{LineNumber: line, Aliases: aliases})
+			rows = append(rows, []string{number, strings.Join(aliases, ",")})
+			values = append(values, number)
 		}
 
-		fmt.Println("📞 Available Lines:")
-		for i, line := range lines {
-			fmt.Printf("  %d. %d\n", i+1, line)
+		// Aliases that point at a number the account does not have are likely typos
+		for _, name := range cfg.LineNames() {
+			if !onAccount[cfg.Lines[name].Number] {
+				notice("⚠️  Line alias %q uses %s, which is not one of the account's lines\n", name, cfg.Lines[name].Number)
+			}
 		}
 
+		return out.Render(output.Result{
+			Data:    results,
+			Columns: []string{"LINE NUMBER", "ALIASES"},
+			Rows:    rows,
+			Text: func(w io.Writer) {
+				if len(lines) == 0 {
+					fmt.Fprintln(w, i18n.T("📞 No lines found"))
+					return
+				}
+
+				fmt.Fprintln(w, i18n.T("📞 Available Lines:"))
+				for i, r := range results {
+					if len(r.Aliases) > 0 {
+						i18n.Fprintf(w, "  %d. %d (%s)\n", i+1, r.LineNumber, strings.Join(r.Aliases, ", "))
+					} else {
+						i18n.Fprintf(w, "  %d. %d\n", i+1, r.LineNumber)
+					}
+				}
+			},
+			Values: values,
+		})
+	},
+}
+
+// linesAliasCmd represents the lines alias command
+var linesAliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage named lines",
+	Long: `Give lines names such as otp, marketing or support. A name can be used
+wherever a line number is expected, e.g. 'smsir send -l marketing', and carries
+per-line defaults: a signature appended to every message, a per-part tariff
+for cost estimates and the hours the line may send in.`,
+}
+
+// linesAliasSetCmd represents the lines alias set command
+var linesAliasSetCmd = &cobra.Command{
+	Use:   "set <name> <number>",
+	Short: "Create or update a named line",
+	Long: `Create or update a named line in the active profile.
+
+Examples:
+  smsir lines alias set otp 3000123
+  smsir lines alias set marketing 30001234 --signature "لغو۱۱" --tariff 1.2 --hours 08:00-21:00
+  smsir lines alias set mci 30005555 --operator mci --weight 3`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profileCfg, err := loadProfileForEdit()
+		if err != nil {
+			return err
+		}
+
+		name, number := args[0], args[1]
+		line := profileCfg.Lines[name]
+		line.Number = number
+		if cmd.Flags().Changed("signature") {
+			line.Signature, _ = cmd.Flags().GetString("signature")
+		}
+		if cmd.Flags().Changed("tariff") {
+			line.Tariff, _ = cmd.Flags().GetFloat64("tariff")
+		}
+		if cmd.Flags().Changed("hours") {
+			line.SendHours, _ = cmd.Flags().GetString("hours")
+		}
+		if cmd.Flags().Changed("operator") {
+			line.Operator, _ = cmd.Flags().GetString("operator")
+		}
+		if cmd.Flags().Changed("weight") {
+			line.Weight, _ = cmd.Flags().GetInt("weight")
+		}
+
+		// Copy the map so a failed validation leaves the loaded profile untouched
+		lines := make(map[string]config.Line, len(profileCfg.Lines)+1)
+		for n, l := range profileCfg.Lines {
+			lines[n] = l
+		}
+		lines[name] = line
+		profileCfg.Lines = lines
+
+		if err := profileCfg.ValidateValues(); err != nil {
+			return i18n.Errorf("invalid line: %w", err)
+		}
+		if err := profileCfg.SaveConfig(); err != nil {
+			return i18n.Errorf("error saving configuration: %w", err)
+		}
+
+		notice("✅ Line %q saved (profile %q)\n", name, profileCfg.Profile)
+		return nil
+	},
+}
+
+// linesAliasRemoveCmd represents the lines alias rm command
+var linesAliasRemoveCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Aliases: []string{"remove"},
+	Short:   "Remove a named line",
+	Long:    `Remove a named line from the active profile`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profileCfg, err := loadProfileForEdit()
+		if err != nil {
+			return err
+		}
+
+		if _, ok := profileCfg.Lines[args[0]]; !ok {
+			return i18n.Errorf("line %q not found", args[0])
+		}
+		if profileCfg.LineNumber == args[0] {
+			return i18n.Errorf("line %q is the profile's default line; change line_number first", args[0])
+		}
+		delete(profileCfg.Lines, args[0])
+
+		if err := profileCfg.SaveConfig(); err != nil {
+			return i18n.Errorf("error saving configuration: %w", err)
+		}
+
+		notice("✅ Line %q removed (profile %q)\n", args[0], profileCfg.Profile)
 		return nil
 	},
 }
+
+// linesAliasListCmd represents the lines alias list command
+var linesAliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List named lines",
+	Long:  `List the named lines of the active profile without calling the API`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := cfg.LineNames()
+
+		results := make([]lineAliasResult, 0, len(names))
+		rows := make([][]string, 0, len(names))
+		for _, name := range names {
+			line := cfg.Lines[name]
+			results = append(results, lineAliasResult{
+				Name:      name,
+				Number:    line.Number,
+				Signature: line.Signature,
+				Tariff:    line.Tariff,
+				SendHours: line.SendHours,
+				Operator:  line.Operator,
+				Weight:    line.Weight,
+			})
+			rows = append(rows, []string{name, line.Number, line.Signature, formatFloat(line.Tariff), line.SendHours,
+				line.Operator, strconv.Itoa(line.Weight)})
+		}
+
+		return out.Render(output.Result{
+			Data:    results,
+			Columns: []string{"NAME", "NUMBER", "SIGNATURE", "TARIFF", "SEND HOURS", "OPERATOR", "WEIGHT"},
+			Rows:    rows,
+			Text: func(w io.Writer) {
+				if len(results) == 0 {
+					fmt.Fprintln(w, i18n.T("📞 No named lines; add one with 'smsir lines alias set <name> <number>'"))
+					return
+				}
+
+				fmt.Fprintln(w, i18n.T("📞 Named Lines:"))
+				for _, r := range results {
+					i18n.Fprintf(w, "  %-12s %s", r.Name, r.Number)
+					if r.SendHours != "" {
+						i18n.Fprintf(w, "  🕒 %s", r.SendHours)
+					}
+					if r.Tariff > 0 {
+						i18n.Fprintf(w, "  💰 %s/part", i18n.Digits(formatFloat(r.Tariff)))
+					}
+					if r.Operator != "" {
+						i18n.Fprintf(w, "  📶 %s", r.Operator)
+					}
+					if r.Weight > 0 {
+						i18n.Fprintf(w, "  ⚖️  %d", r.Weight)
+					}
+					if r.Signature != "" {
+						i18n.Fprintf(w, "  ✍️  %s", r.Signature)
+					}
+					fmt.Fprintln(w)
+				}
+			},
+			Values: names,
+		})
+	},
+}
+
+func init() {
+	// Note: linesCmd is added to rootCmd in root.go setupCommands() to control order
+	linesCmd.AddCommand(linesAliasCmd)
+	linesAliasCmd.AddCommand(linesAliasSetCmd)
+	linesAliasCmd.AddCommand(linesAliasRemoveCmd)
+	linesAliasCmd.AddCommand(linesAliasListCmd)
+
+	linesAliasSetCmd.Flags().String("signature", "", "text appended to every message sent from the line")
+	linesAliasSetCmd.Flags().Float64("tariff", 0, "cost per message part, used for cost estimates")
+	linesAliasSetCmd.Flags().String("hours", "", "hours the line may send in, e.g. 08:00-21:00")
+	linesAliasSetCmd.Flags().String("operator", "", "operator the line serves with 'send --strategy operator': mci, irancell or rightel")
+	linesAliasSetCmd.Flags().Int("weight", 0, "share of recipients with 'send --strategy weighted' (default 1)")
+}
+
+// lineResult is the structured output of one line in the lines command
+type lineResult struct {
+	LineNumber int64    `json:"lineNumber"`
+	Aliases    []string `json:"aliases,omitempty"`
+}
+
+// lineAliasResult is the structured output of one named line
+type lineAliasResult struct {
+	Name      string  `json:"name"`
+	Number    string  `json:"number"`
+	Signature string  `json:"signature,omitempty"`
+	Tariff    float64 `json:"tariff,omitempty"`
+	SendHours string  `json:"sendHours,omitempty"`
+	Operator  string  `json:"operator,omitempty"`
+	Weight    int     `json:"weight,omitempty"`
+}
@@ -0,0 +1,308 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/blocklist"
+	"github.com/SaneiyanReza/smsir-cli/internal/history"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/output"
+	"github.com/SaneiyanReza/smsir-cli/internal/phone"
+	"github.com/SaneiyanReza/smsir-cli/internal/state"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// optOutCursorKey is the state key holding the last processed receive timestamp
+	optOutCursorKey = "optout.cursor"
+	// optOutPendingKey is the state key holding the confirmations still to be sent, per line
+	optOutPendingKey = "optout.pending"
+	// optOutSource marks blocklist entries added by the sync command
+	optOutSource = "optout-sync"
+)
+
+// optOutCmd represents the optout command
+var optOutCmd = &cobra.Command{
+	Use:   "optout",
+	Short: "Opt-out (blocklist) management",
+	Long:  `Manage the local blocklist of numbers that asked to stop receiving messages`,
+}
+
+// optOutSyncCmd represents the optout sync command
+var optOutSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Process opt-out replies from received messages",
+	Long: `Read received messages since the last run and add senders whose reply matches
+an opt-out keyword (e.g. "لغو" or "STOP") to the local blocklist.
+
+The position of the last processed message is saved, so the command can run from cron.
+If optout.confirm_message is configured, a confirmation SMS is sent to every newly
+blocked number from the line it replied to. Confirmations that fail to send are
+kept and retried by the next run.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, _ := cmd.Flags().GetDuration("since")
+		pageSize, _ := cmd.Flags().GetInt("page-size")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		noConfirm, _ := cmd.Flags().GetBool("no-confirm")
+
+		if err := cfg.Validate(); err != nil {
+			return i18n.Errorf("invalid configuration: %w", err)
+		}
+
+		st, err := state.Load()
+		if err != nil {
+			return i18n.Errorf("error loading state: %w", err)
+		}
+
+		bl, err := blocklist.Load()
+		if err != nil {
+			return i18n.Errorf("error loading blocklist: %w", err)
+		}
+
+		var fromDate int64
+		if _, err := st.Get(optOutCursorKey, &fromDate); err != nil {
+			return err
+		}
+		if fromDate == 0 {
+			fromDate = time.Now().Add(-since).Unix()
+		}
+		toDate := time.Now().Unix()
+
+		client := api.NewClient(cfg)
+
+		scanned := 0
+		added := make(map[int64][]string) // line number -> newly blocked mobiles
+		for page := 1; ; page++ {
+			resp, err := client.GetReceivedArchive(page, pageSize, fromDate, toDate)
+			if err != nil {
+				return i18n.Errorf("error getting received messages: %w", err)
+			}
+			if !resp.IsSuccess() {
+				return i18n.Errorf("API error: %s", resp.GetStatusMessage())
+			}
+
+			for _, msg := range resp.Data {
+				scanned++
+				if !blocklist.IsOptOut(msg.MessageText, cfg.OptOut.Keywords) {
+					continue
+				}
+
+				mobile := phone.FromInt(msg.Mobile)
+				isNew, err := bl.Add(mobile, msg.MessageText, optOutSource)
+				if err != nil {
+					notice("⚠️  Skipping %s: %v\n", mobile, err)
+					continue
+				}
+				if isNew {
+					added[msg.Number] = append(added[msg.Number], mobile)
+					notice("⛔ %s opted out (%q)\n", mobile, msg.MessageText)
+				}
+			}
+
+			if len(resp.Data) < pageSize {
+				break
+			}
+		}
+
+		total := 0
+		for _, mobiles := range added {
+			total += len(mobiles)
+		}
+
+		if dryRun {
+			notice("🔍 Dry run: %d message(s) scanned, %d number(s) would be blocked\n", scanned, total)
+			return nil
+		}
+
+		// Confirmations that could not be sent last time are retried with the new ones
+		pending := make(map[int64][]string)
+		if _, err := st.Get(optOutPendingKey, &pending); err != nil {
+			return err
+		}
+		if !noConfirm && cfg.OptOut.ConfirmMessage != "" {
+			for lineNumber, mobiles := range added {
+				pending[lineNumber] = append(pending[lineNumber], mobiles...)
+			}
+		}
+
+		if err := bl.Save(); err != nil {
+			return i18n.Errorf("error saving blocklist: %w", err)
+		}
+		if err := saveOptOutState(st, optOutPendingKey, pending); err != nil {
+			return err
+		}
+
+		if !noConfirm && cfg.OptOut.ConfirmMessage != "" {
+			for lineNumber, mobiles := range pending {
+				req := api.BulkSendRequest{
+					LineNumber:  lineNumber,
+					MessageText: cfg.OptOut.ConfirmMessage,
+					Mobiles:     mobiles,
+				}
+
+				resp, err := client.SendBulk(req)
+				if err != nil {
+					return i18n.Errorf("error sending confirmation SMS: %w", err)
+				}
+				if !resp.IsSuccess() {
+					return i18n.Errorf("API error: %s", resp.GetStatusMessage())
+				}
+
+				delete(pending, lineNumber)
+				if err := saveOptOutState(st, optOutPendingKey, pending); err != nil {
+					return err
+				}
+
+				record := &history.Record{
+					Profile:     cfg.Profile,
+					LineNumber:  lineNumber,
+					MessageText: req.MessageText,
+					Recipients:  mobiles,
+					PackID:      resp.Data.PackID,
+					MessageIDs:  resp.Data.MessageIds,
+					Cost:        resp.Data.Cost,
+					Origin:      history.OriginBatch,
+					Tags:        []string{"optout"},
+				}
+				if err := history.Save(record); err != nil {
+					i18n.Fprintf(os.Stderr, "⚠️  Could not save to history: %v\n", err)
+				}
+				notice("📤 Confirmation sent to %d number(s) from line %d\n", len(mobiles), lineNumber)
+			}
+		}
+
+		// The cursor only moves once every confirmation went out
+		if err := saveOptOutState(st, optOutCursorKey, toDate); err != nil {
+			return err
+		}
+
+		notice("✅ %d message(s) scanned, %d number(s) added to blocklist\n", scanned, total)
+		return nil
+	},
+}
+
+// saveOptOutState stores a value of the sync in the state file
+func saveOptOutState(st *state.State, key string, value interface{}) error {
+	if err := st.Set(key, value); err != nil {
+		return err
+	}
+	if err := st.Save(); err != nil {
+		return i18n.Errorf("error saving state: %w", err)
+	}
+	return nil
+}
+
+// optOutListCmd represents the optout list command
+var optOutListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show blocklisted numbers",
+	Long:  `Show all numbers on the local blocklist`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bl, err := blocklist.Load()
+		if err != nil {
+			return i18n.Errorf("error loading blocklist: %w", err)
+		}
+
+		entries := bl.Entries()
+
+		var rows [][]string
+		var values []string
+		for _, e := range entries {
+			rows = append(rows, []string{e.Mobile, e.AddedAt.Format("2006-01-02 15:04"), e.Source, e.Reason})
+			values = append(values, e.Mobile)
+		}
+
+		return out.Render(output.Result{
+			Data:    entries,
+			Columns: []string{"MOBILE", "ADDED", "SOURCE", "REASON"},
+			Rows:    rows,
+			Text: func(w io.Writer) {
+				if len(entries) == 0 {
+					fmt.Fprintln(w, i18n.T("⛔ Blocklist is empty"))
+					return
+				}
+
+				fmt.Fprintln(w, i18n.T("⛔ Blocklisted Numbers:"))
+				for i, e := range entries {
+					i18n.Fprintf(w, "  %d. %s  %s  (%s, %s)\n", i+1, e.Mobile, e.AddedAt.Format("2006-01-02 15:04"), e.Source, e.Reason)
+				}
+			},
+			Values: values,
+		})
+	},
+}
+
+// optOutAddCmd represents the optout add command
+var optOutAddCmd = &cobra.Command{
+	Use:   "add <mobile>...",
+	Short: "Add numbers to the blocklist",
+	Long:  `Add one or more mobile numbers to the local blocklist`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reason, _ := cmd.Flags().GetString("reason")
+
+		bl, err := blocklist.Load()
+		if err != nil {
+			return i18n.Errorf("error loading blocklist: %w", err)
+		}
+
+		for _, mobile := range args {
+			if _, err := bl.Add(mobile, reason, "manual"); err != nil {
+				return err
+			}
+		}
+
+		if err := bl.Save(); err != nil {
+			return i18n.Errorf("error saving blocklist: %w", err)
+		}
+
+		notice("✅ %d number(s) added to blocklist\n", len(args))
+		return nil
+	},
+}
+
+// optOutRemoveCmd represents the optout remove command
+var optOutRemoveCmd = &cobra.Command{
+	Use:   "remove <mobile>...",
+	Short: "Remove numbers from the blocklist",
+	Long:  `Remove one or more mobile numbers from the local blocklist`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bl, err := blocklist.Load()
+		if err != nil {
+			return i18n.Errorf("error loading blocklist: %w", err)
+		}
+
+		removed := 0
+		for _, mobile := range args {
+			if bl.Remove(mobile) {
+				removed++
+			}
+		}
+
+		if err := bl.Save(); err != nil {
+			return i18n.Errorf("error saving blocklist: %w", err)
+		}
+
+		notice("✅ %d number(s) removed from blocklist\n", removed)
+		return nil
+	},
+}
+
+func init() {
+	optOutCmd.AddCommand(optOutSyncCmd)
+	optOutCmd.AddCommand(optOutListCmd)
+	optOutCmd.AddCommand(optOutAddCmd)
+	optOutCmd.AddCommand(optOutRemoveCmd)
+
+	optOutSyncCmd.Flags().Duration("since", 24*time.Hour, "How far back to look on the first run")
+	optOutSyncCmd.Flags().Int("page-size", 100, "Number of messages fetched per request")
+	optOutSyncCmd.Flags().Bool("dry-run", false, "Show matches without changing the blocklist")
+	optOutSyncCmd.Flags().Bool("no-confirm", false, "Do not send the confirmation SMS")
+
+	optOutAddCmd.Flags().String("reason", "", "Why the number is blocked")
+}
@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Profile (account) management",
+	Long: `Manage named profiles, e.g. separate SMS.ir accounts for marketing, OTP and staging.
+
+Commands use the default profile unless another is chosen with --profile or SMSIR_PROFILE.`,
+}
+
+// profileListCmd represents the profile list command
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Long:  `List all profiles and show which one is the default and which one is active`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := config.LoadFile()
+		if err != nil {
+			return i18n.Errorf("error loading configuration: %w", err)
+		}
+
+		active := f.ActiveProfile()
+		var results []profileResult
+		var rows [][]string
+		for _, name := range f.Names() {
+			p := f.Profiles[name]
+			result := profileResult{
+				Name:       name,
+				LineNumber: p.LineNumber,
+				BaseURL:    p.BaseURL,
+				Default:    name == f.DefaultProfile,
+				Active:     name == active,
+			}
+			results = append(results, result)
+			rows = append(rows, []string{name, p.LineNumber, p.BaseURL, fmt.Sprint(result.Default), fmt.Sprint(result.Active)})
+		}
+
+		return out.Render(output.Result{
+			Data:    results,
+			Columns: []string{"NAME", "LINE NUMBER", "BASE URL", "DEFAULT", "ACTIVE"},
+			Rows:    rows,
+			Text: func(w io.Writer) {
+				fmt.Fprintln(w, i18n.T("👤 Profiles:"))
+				for _, r := range results {
+					marker := " "
+					if r.Active {
+						marker = "*"
+					}
+					suffix := ""
+					if r.Default {
+						suffix = " (default)"
+					}
+					i18n.Fprintf(w, "  %s %s%s  line %s\n", marker, r.Name, suffix, r.LineNumber)
+				}
+			},
+			Values: f.Names(),
+		})
+	},
+}
+
+// profileAddCmd represents the profile add command
+var profileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a profile",
+	Long:  `Add a new profile with its own API Key and line number`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey, _ := cmd.Flags().GetString("api-key")
+		lineNumber, _ := cmd.Flags().GetString("line")
+		baseURL, _ := cmd.Flags().GetString("base-url")
+
+		f, err := config.LoadFile()
+		if err != nil {
+			return i18n.Errorf("error loading configuration: %w", err)
+		}
+
+		p := config.DefaultConfig()
+		p.APIKey = apiKey
+		p.LineNumber = lineNumber
+		if baseURL != "" {
+			p.BaseURL = baseURL
+		}
+
+		if err := f.Add(args[0], p); err != nil {
+			return err
+		}
+		if err := f.Save(); err != nil {
+			return i18n.Errorf("error saving configuration: %w", err)
+		}
+
+		notice("✅ Profile %q added\n", args[0])
+		return nil
+	},
+}
+
+// profileUseCmd represents the profile use command
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the default profile",
+	Long:  `Make a profile the default for all commands`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := config.LoadFile()
+		if err != nil {
+			return i18n.Errorf("error loading configuration: %w", err)
+		}
+
+		if err := f.Use(args[0]); err != nil {
+			return err
+		}
+		if err := f.Save(); err != nil {
+			return i18n.Errorf("error saving configuration: %w", err)
+		}
+
+		notice("✅ Default profile is now %q\n", args[0])
+		return nil
+	},
+}
+
+// profileRemoveCmd represents the profile rm command
+var profileRemoveCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Aliases: []string{"remove"},
+	Short:   "Remove a profile",
+	Long:    `Remove a profile and its settings`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := config.LoadFile()
+		if err != nil {
+			return i18n.Errorf("error loading configuration: %w", err)
+		}
+
+		if err := f.Remove(args[0]); err != nil {
+			return err
+		}
+		if err := f.Save(); err != nil {
+			return i18n.Errorf("error saving configuration: %w", err)
+		}
+
+		notice("✅ Profile %q removed\n", args[0])
+		return nil
+	},
+}
+
+// profileRenameCmd represents the profile rename command
+var profileRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a profile",
+	Long:  `Rename a profile, keeping it the default if it was`,
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := config.LoadFile()
+		if err != nil {
+			return i18n.Errorf("error loading configuration: %w", err)
+		}
+
+		if err := f.Rename(args[0], args[1]); err != nil {
+			return err
+		}
+		if err := f.Save(); err != nil {
+			return i18n.Errorf("error saving configuration: %w", err)
+		}
+
+		notice("✅ Profile %q renamed to %q\n", args[0], args[1])
+		return nil
+	},
+}
+
+// profileResult is the structured output of one profile in the profile list command
+type profileResult struct {
+	Name       string `json:"name"`
+	LineNumber string `json:"line_number"`
+	BaseURL    string `json:"base_url"`
+	Default    bool   `json:"default"`
+	Active     bool   `json:"active"`
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileAddCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileRemoveCmd)
+	profileCmd.AddCommand(profileRenameCmd)
+
+	profileAddCmd.Flags().String("api-key", "", "API Key from SMS.ir panel")
+	profileAddCmd.Flags().String("line", "", "Line number")
+	profileAddCmd.Flags().String("base-url", "", "API base URL (optional)")
+}
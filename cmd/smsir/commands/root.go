@@ -5,11 +5,14 @@ import (
 	"os"
 
 	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
 var (
 	cfg     *config.Config
+	out     *output.Renderer
 	RootCmd = &cobra.Command{
 		Use:   "smsir",
 		Short: "SMS.ir CLI - A simple message can connect worlds with a single command",
@@ -31,6 +34,11 @@ Quick Start:
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
+	if err := setupLanguage(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
 	err := RootCmd.Execute()
 	if err != nil {
 		os.Exit(1)
@@ -39,20 +47,49 @@ func Execute() {
 
 func init() {
 	RootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		// Skip config loading for config set command
-		if cmd.Name() == "set" {
+		format, _ := cmd.Flags().GetString("output")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+
+		var err error
+		out, err = output.New(format, quiet, os.Stdout)
+		if err != nil {
+			return err
+		}
+
+		configFile, _ := cmd.Flags().GetString("config")
+		config.SetConfigPath(configFile)
+
+		profile, _ := cmd.Flags().GetString("profile")
+		config.SetProfile(profile)
+
+		// Skip config loading for commands that edit the config file themselves,
+		// and for the menu, which loads it when a screen needs it and offers the
+		// config wizard when that fails
+		if cmd == configSetCmd || cmd == configUnsetCmd || cmd == configEditCmd ||
+			cmd == configDoctorCmd || cmd == configBackendCmd || cmd.Parent() == profileCmd ||
+			cmd == linesAliasSetCmd || cmd == linesAliasRemoveCmd || cmd == selectorCmd {
+			// They only edit the user file, so a project file they cannot use is
+			// reported but not fatal; the doctor and the menu report it themselves
+			if _, err := config.LoadProject(); err != nil && cmd != configDoctorCmd && cmd != selectorCmd {
+				notice("⚠️  %v\n", err)
+			}
 			return nil
 		}
 
-		var err error
 		cfg, err = config.LoadConfig()
 		if err != nil {
-			return fmt.Errorf("error loading configuration: %w", err)
+			return i18n.Errorf("error loading configuration: %w", err)
 		}
 		return nil
 	}
 
 	RootCmd.PersistentFlags().BoolP("verbose", "v", false, "show more details")
+	RootCmd.PersistentFlags().String("config", "", "config file to use (default from SMSIR_CONFIG or the config directory)")
+	RootCmd.PersistentFlags().StringP("profile", "p", "", "profile to use (default from config or SMSIR_PROFILE)")
+	RootCmd.PersistentFlags().StringP("output", "o", "", "output format: table, json, yaml, csv or tsv")
+	RootCmd.PersistentFlags().BoolP("quiet", "q", false, "print only values, e.g. the bare credit number")
+	RootCmd.PersistentFlags().String("lang", "", "language: en or fa (default from SMSIR_LANG or the locale)")
+	RootCmd.PersistentFlags().String("digits", "", "digits for numbers: latin or persian (default from SMSIR_DIGITS)")
 
 	// Disable completion command
 	RootCmd.CompletionOptions.DisableDefaultCmd = true
@@ -64,18 +101,36 @@ func init() {
 	setupCommands()
 }
 
+// notice prints progress and warnings; they go to stderr when the output is meant for scripts
+func notice(format string, a ...interface{}) {
+	if out != nil && out.Structured() {
+		i18n.Fprintf(os.Stderr, format, a...)
+		return
+	}
+	i18n.Printf(format, a...)
+}
+
 // setupCommands adds all commands to rootCmd in the desired order
 func setupCommands() {
 	// Configuration first (most important)
 	RootCmd.AddCommand(configCmd)
+	RootCmd.AddCommand(profileCmd)
 
 	// Send command
 	RootCmd.AddCommand(sendCmd)
 
+	// Delivery tracking for sent packs
+	RootCmd.AddCommand(watchCmd)
+
 	// Then credit and lines
 	RootCmd.AddCommand(creditCmd)
 	RootCmd.AddCommand(linesCmd)
 
+	// Local history, blocklist and contacts management
+	RootCmd.AddCommand(historyCmd)
+	RootCmd.AddCommand(optOutCmd)
+	RootCmd.AddCommand(contactsCmd)
+
 	// Menu last (UI access point)
 	RootCmd.AddCommand(selectorCmd)
 }
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
 	"github.com/SaneiyanReza/smsir-cli/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -12,7 +13,15 @@ var selectorCmd = &cobra.Command{
 	Short: "Launch interactive menu",
 	Long:  `Launch interactive menu with beautiful user interface to choose between different modes: dashboard with real-time updates, configuration setup, and command line operations. This provides an easy-to-use graphical interface for navigating all SMS.ir CLI features.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ui.LoadBidi()
 		launcher := ui.NewLauncherModel()
+
+		// The wizards may store an API key; the passphrase cannot be asked for once the UI owns the terminal
+		if err := config.UnlockSecrets(); err != nil {
+			launcher = launcher.WithConfigError(err)
+		} else if _, err := config.LoadProject(); err != nil {
+			launcher = launcher.WithConfigError(err)
+		}
 		p := tea.NewProgram(launcher, tea.WithAltScreen())
 
 		finalModel, err := p.Run()
@@ -2,10 +2,21 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/blocklist"
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/history"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/output"
+	"github.com/SaneiyanReza/smsir-cli/internal/routing"
+	"github.com/SaneiyanReza/smsir-cli/internal/sms"
+	"github.com/SaneiyanReza/smsir-cli/internal/state"
 	"github.com/spf13/cobra"
 )
 
@@ -18,18 +29,24 @@ var sendCmd = &cobra.Command{
 
 		message, err := cmd.Flags().GetString("message")
 		if err != nil {
-			return fmt.Errorf("error getting message flag: %w", err)
+			return i18n.Errorf("error getting message flag: %w", err)
 		}
 		if message == "" {
-			return fmt.Errorf("message is required")
+			return i18n.Errorf("message is required")
 		}
 
 		mobilesStr, err := cmd.Flags().GetString("to")
 		if err != nil {
-			return fmt.Errorf("error getting to flag: %w", err)
+			return i18n.Errorf("error getting to flag: %w", err)
 		}
 		if mobilesStr == "" {
-			return fmt.Errorf("to mobiles is required")
+			return i18n.Errorf("to mobiles is required")
+		}
+
+		// Parsed once, so a delay such as 2h is one time for every line and the output
+		sendAt, err := scheduledAt(cmd)
+		if err != nil {
+			return err
 		}
 
 		mobiles := strings.Split(mobilesStr, ",")
@@ -37,56 +54,363 @@ var sendCmd = &cobra.Command{
 			mobiles[i] = strings.TrimSpace(mobiles[i])
 		}
 
+		bl, err := blocklist.Load()
+		if err != nil {
+			return i18n.Errorf("error loading blocklist: %w", err)
+		}
+		mobiles, blocked := bl.Filter(mobiles)
+		if len(blocked) > 0 {
+			notice("⛔ Skipping %d blocklisted number(s): %s\n", len(blocked), strings.Join(blocked, ", "))
+		}
+		if len(mobiles) == 0 {
+			return i18n.Errorf("all recipients are blocklisted")
+		}
+
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+
+		chain, err := failoverChain(cmd)
+		if err != nil {
+			return err
+		}
+
+		lineSpecs, _ := cmd.Flags().GetStringSlice("lines")
+		if len(lineSpecs) > 0 {
+			return sendSplit(cmd, client, message, mobiles, blocked, lineSpecs, tags, chain, sendAt)
+		}
+
 		lineNumberStr, err := cmd.Flags().GetString("line")
 		if err != nil {
-			return fmt.Errorf("error getting line flag: %w", err)
+			return i18n.Errorf("error getting line flag: %w", err)
 		}
 
-		var lineNumber int64
-		if lineNumberStr != "" {
-			lineNumber, err = strconv.ParseInt(lineNumberStr, 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid line number: %w", err)
-			}
-		} else {
+		if lineNumberStr == "" {
 			if cfg.LineNumber == "" {
-				return fmt.Errorf("line number is required (use --line flag or configure it)")
-			}
-			lineNumber, err = strconv.ParseInt(cfg.LineNumber, 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid line number in config: %w", err)
+				return i18n.Errorf("line number is required (use --line flag or configure it)")
 			}
+			lineNumberStr = cfg.LineNumber
 		}
 
-		req := api.BulkSendRequest{
-			LineNumber:  lineNumber,
-			MessageText: message,
-			Mobiles:     mobiles,
+		line, err := cfg.ResolveLine(lineNumberStr)
+		if err != nil {
+			return err
 		}
 
-		resp, err := client.SendBulk(req)
+		text, err := prepareMessage(cmd, line, message, len(mobiles), sendAt)
 		if err != nil {
-			return fmt.Errorf("error sending SMS: %w", err)
+			return err
 		}
 
-		if !resp.IsSuccess() {
-			return fmt.Errorf("API error: %s", resp.GetStatusMessage())
+		sent, err := sendWithFailover(cmd, client, line, message, text, mobiles, tags, chain, sendAt)
+		if err != nil {
+			return err
+		}
+		resp := sent.resp
+		lineNumber, _ := sent.line.Int()
+		if sent.profile == cfg.Profile {
+			// Preselected by the line pickers of the interactive wizards
+			_ = state.SetLastLine(cfg.Profile, sent.line.Number)
 		}
 
-		fmt.Printf("✅ SMS sent successfully!\n")
-		fmt.Printf("📦 Pack ID: %s\n", resp.Data.PackID)
-		fmt.Printf("💰 Cost: %.2f SMS\n", resp.Data.Cost)
-		fmt.Printf("📱 Message IDs: %v\n", resp.Data.MessageIds)
-		fmt.Printf("📊 Total messages: %d\n", len(resp.Data.MessageIds))
+		result := sendResult{
+			PackID:     resp.Data.PackID,
+			MessageIDs: resp.Data.MessageIds,
+			Cost:       resp.Data.Cost,
+			LineNumber: lineNumber,
+			Profile:    sent.profile,
+			Recipients: mobiles,
+			Blocked:    blocked,
+		}
+		if result.MessageIDs == nil {
+			result.MessageIDs = []int32{}
+		}
+		if result.Blocked == nil {
+			result.Blocked = []string{}
+		}
 
-		return nil
+		var rows [][]string
+		for i, id := range resp.Data.MessageIds {
+			mobile := ""
+			if i < len(mobiles) {
+				mobile = mobiles[i]
+			}
+			rows = append(rows, []string{resp.Data.PackID, strconv.FormatInt(int64(id), 10), mobile})
+		}
+
+		return out.Render(output.Result{
+			Data:    result,
+			Columns: []string{"PACK ID", "MESSAGE ID", "MOBILE"},
+			Rows:    rows,
+			Text: func(w io.Writer) {
+				i18n.Fprintf(w, "✅ SMS sent successfully!\n")
+				if !sendAt.IsZero() {
+					i18n.Fprintf(w, "🕒 Scheduled for %s\n", sendAt.Format("2006-01-02 15:04"))
+				}
+				if sent.profile != cfg.Profile {
+					i18n.Fprintf(w, "↪️  Sent by failover profile %q from line %s\n", sent.profile, sent.line.Label())
+				}
+				i18n.Fprintf(w, "📦 Pack ID: %s\n", resp.Data.PackID)
+				i18n.Fprintf(w, "💰 Cost: %.2f SMS\n", resp.Data.Cost)
+				i18n.Fprintf(w, "📱 Message IDs: %v\n", resp.Data.MessageIds)
+				i18n.Fprintf(w, "📊 Total messages: %d\n", len(resp.Data.MessageIds))
+			},
+			Values: []string{resp.Data.PackID},
+		})
 	},
 }
 
+// prepareMessage checks the line's send hours and signs the message for it,
+// printing the estimated cost when the line has a tariff
+func prepareMessage(cmd *cobra.Command, line config.Line, message string, recipients int, sendAt time.Time) (string, error) {
+	if err := checkHours(cmd, line, sendAt); err != nil {
+		return "", err
+	}
+	message = signFor(cmd, line, message)
+
+	if line.Tariff > 0 {
+		notice("💡 Estimated cost from %s: %s (%d part(s) × %d recipient(s) × %s)\n", line.Label(),
+			i18n.Digits(formatFloat(sms.EstimateCost(message, recipients, line.Tariff))),
+			sms.Segments(message), recipients, i18n.Digits(formatFloat(line.Tariff)))
+	}
+	return message, nil
+}
+
+// checkHours returns an error when the line may not send now, or at sendAt
+// for a scheduled message, unless --ignore-hours is given
+func checkHours(cmd *cobra.Command, line config.Line, sendAt time.Time) error {
+	if ignoreHours, _ := cmd.Flags().GetBool("ignore-hours"); ignoreHours {
+		return nil
+	}
+	at := sendAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	if err := line.CheckHours(at); err != nil {
+		return i18n.Errorf("%w (use --ignore-hours to send anyway)", err)
+	}
+	return nil
+}
+
+// scheduledAt returns the time given with --at, or the zero time to send right
+// away. The time is either a date and time or a delay such as 30m or 2h.
+func scheduledAt(cmd *cobra.Command) (time.Time, error) {
+	value, _ := cmd.Flags().GetString("at")
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	at, err := time.ParseInLocation("2006-01-02 15:04", value, time.Local)
+	if err != nil {
+		if at, err = time.Parse(time.RFC3339, value); err != nil {
+			d, durErr := time.ParseDuration(value)
+			if durErr != nil {
+				return time.Time{}, i18n.Errorf("invalid --at %q: expected a time like 2006-01-02 15:04 or a delay like 2h", value)
+			}
+			at = time.Now().Add(d)
+		}
+	}
+
+	if !at.After(time.Now()) {
+		return time.Time{}, i18n.Errorf("--at %s is not in the future", at.Format("2006-01-02 15:04"))
+	}
+	return at, nil
+}
+
+// signFor appends the line's signature, unless --no-signature is given
+func signFor(cmd *cobra.Command, line config.Line, message string) string {
+	if noSignature, _ := cmd.Flags().GetBool("no-signature"); noSignature {
+		return message
+	}
+	return line.Sign(message)
+}
+
+// sendFromLine sends one bulk request with a profile's client and records it
+// in history. A non-zero sendAt schedules the message instead of sending it now.
+func sendFromLine(client *api.Client, profile string, line config.Line, message string, mobiles, tags []string, sendAt time.Time) (*api.APIResponse[api.BulkSendResponse], error) {
+	lineNumber, err := line.Int()
+	if err != nil {
+		return nil, err
+	}
+
+	req := api.BulkSendRequest{
+		LineNumber:  lineNumber,
+		MessageText: message,
+		Mobiles:     mobiles,
+	}
+	if !sendAt.IsZero() {
+		unix := sendAt.Unix()
+		req.SendDateTime = &unix
+	}
+	resp, err := client.SendBulk(req)
+	if err != nil {
+		return nil, i18n.Errorf("error sending SMS: %w", err)
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+
+	record := &history.Record{
+		Profile:     profile,
+		LineNumber:  lineNumber,
+		MessageText: message,
+		Recipients:  mobiles,
+		PackID:      resp.Data.PackID,
+		MessageIDs:  resp.Data.MessageIds,
+		Cost:        resp.Data.Cost,
+		Origin:      history.OriginCLI,
+		Tags:        tags,
+		SendAt:      sendAt,
+	}
+	if err := history.Save(record); err != nil {
+		i18n.Fprintf(os.Stderr, "⚠️  Could not save to history: %v\n", err)
+	}
+	return resp, nil
+}
+
+// sendSplit spreads the recipients over several lines and sends one request per line.
+// A failing line does not stop the others; the command fails if any line failed.
+func sendSplit(cmd *cobra.Command, client *api.Client, message string, mobiles, blocked, lineSpecs, tags, chain []string, sendAt time.Time) error {
+	strategyName, _ := cmd.Flags().GetString("strategy")
+	strategy, err := routing.ParseStrategy(strategyName)
+	if err != nil {
+		return err
+	}
+
+	lines, err := routing.ParseLines(cfg, lineSpecs)
+	if err != nil {
+		return err
+	}
+	batches, err := routing.Split(strategy, lines, mobiles)
+	if err != nil {
+		return err
+	}
+
+	// Check every line before sending anything, so a closed line does not leave a half-sent campaign
+	texts := make([]string, len(batches))
+	for i, b := range batches {
+		if texts[i], err = prepareMessage(cmd, b.Line, message, len(b.Mobiles), sendAt); err != nil {
+			return err
+		}
+	}
+
+	results := make([]lineSendResult, 0, len(batches))
+	var rows [][]string
+	var packIDs []string
+	failed := 0
+	for i, b := range batches {
+		result := lineSendResult{
+			Line:       b.Line.Number,
+			Alias:      b.Line.Name,
+			Profile:    cfg.Profile,
+			Recipients: b.Mobiles,
+			MessageIDs: []int32{},
+		}
+
+		sent, err := sendWithFailover(cmd, client, b.Line, message, texts[i], b.Mobiles, tags, chain, sendAt)
+		if err != nil {
+			failed++
+			result.Error = err.Error()
+		} else {
+			resp := sent.resp
+			result.Line, result.Alias, result.Profile = sent.line.Number, sent.line.Name, sent.profile
+			result.PackID = resp.Data.PackID
+			result.Cost = resp.Data.Cost
+			if resp.Data.MessageIds != nil {
+				result.MessageIDs = resp.Data.MessageIds
+			}
+			packIDs = append(packIDs, resp.Data.PackID)
+		}
+
+		results = append(results, result)
+		rows = append(rows, []string{result.label(), result.Profile, strconv.Itoa(len(b.Mobiles)), result.PackID,
+			strconv.FormatFloat(result.Cost, 'f', 2, 64), result.Error})
+	}
+
+	if blocked == nil {
+		blocked = []string{}
+	}
+	err = out.Render(output.Result{
+		Data:    splitSendResult{Strategy: string(strategy), Lines: results, Blocked: blocked},
+		Columns: []string{"LINE", "PROFILE", "RECIPIENTS", "PACK ID", "COST", "ERROR"},
+		Rows:    rows,
+		Text: func(w io.Writer) {
+			i18n.Fprintf(w, "📤 Sent from %d line(s) (%s):\n", len(results), strategy)
+			if !sendAt.IsZero() {
+				i18n.Fprintf(w, "🕒 Scheduled for %s\n", sendAt.Format("2006-01-02 15:04"))
+			}
+			for _, r := range results {
+				label := r.label()
+				if r.Profile != cfg.Profile {
+					label += i18n.Sprintf(" via failover profile %q", r.Profile)
+				}
+				if r.Error != "" {
+					i18n.Fprintf(w, "  ❌ %s: %d recipient(s), %s\n", label, len(r.Recipients), r.Error)
+					continue
+				}
+				i18n.Fprintf(w, "  ✅ %s: %d recipient(s), pack %s, cost %.2f SMS\n", label, len(r.Recipients), r.PackID, r.Cost)
+			}
+		},
+		Values: packIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return i18n.Errorf("%d of %d line(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// sendResult is the structured output of the send command
+type sendResult struct {
+	PackID     string   `json:"packId"`
+	MessageIDs []int32  `json:"messageIds"`
+	Cost       float64  `json:"cost"`
+	LineNumber int64    `json:"lineNumber"`
+	Profile    string   `json:"profile"`
+	Recipients []string `json:"recipients"`
+	Blocked    []string `json:"blocked"`
+}
+
+// splitSendResult is the structured output of a send spread over several lines
+type splitSendResult struct {
+	Strategy string           `json:"strategy"`
+	Lines    []lineSendResult `json:"lines"`
+	Blocked  []string         `json:"blocked"`
+}
+
+// lineSendResult is the outcome of the part of a send that went out from one line
+type lineSendResult struct {
+	Line       string   `json:"line"`
+	Alias      string   `json:"alias,omitempty"`
+	Profile    string   `json:"profile"`
+	Recipients []string `json:"recipients"`
+	PackID     string   `json:"packId,omitempty"`
+	MessageIDs []int32  `json:"messageIds"`
+	Cost       float64  `json:"cost"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// label returns the line number with its alias
+func (r lineSendResult) label() string {
+	if r.Alias == "" {
+		return r.Line
+	}
+	return fmt.Sprintf("%s (%s)", r.Line, r.Alias)
+}
+
 func init() {
 	sendCmd.Flags().StringP("message", "m", "", "Message text to send")
 	sendCmd.Flags().StringP("to", "t", "", "Comma-separated list of mobile numbers")
-	sendCmd.Flags().StringP("line", "l", "", "Line number (optional, uses config if not provided)")
+	sendCmd.Flags().StringP("line", "l", "", "Line number or line alias (optional, uses config if not provided)")
+	sendCmd.Flags().Bool("no-signature", false, "Do not append the line's signature")
+	sendCmd.Flags().Bool("ignore-hours", false, "Send even outside the line's allowed send hours")
+	sendCmd.Flags().String("at", "", "Schedule the message for this time (2006-01-02 15:04) or after this delay (e.g. 30m, 2h)")
+	sendCmd.Flags().StringSlice("tag", nil, "Tag stored with the message in history (repeatable)")
+	sendCmd.Flags().StringSlice("lines", nil, "Spread the recipients over these lines or aliases, e.g. otp,marketing:3")
+	sendCmd.Flags().String("strategy", string(routing.RoundRobin), "How --lines splits the recipients: round-robin, weighted or operator")
+	sendCmd.MarkFlagsMutuallyExclusive("line", "lines")
+	sendCmd.Flags().Bool("failover", false, "On credit or API key errors, send from the profiles in failover.profiles (default from failover.commands)")
 
 	sendCmd.MarkFlagRequired("message")
 	sendCmd.MarkFlagRequired("to")
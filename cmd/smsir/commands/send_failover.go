@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"slices"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// delivery is the account and line a message went out from
+type delivery struct {
+	profile string
+	line    config.Line
+	resp    *api.APIResponse[api.BulkSendResponse]
+}
+
+// failoverChain returns the profiles a command falls back to, or nil when
+// failover is off. --failover decides; without it failover.commands does.
+func failoverChain(cmd *cobra.Command) ([]string, error) {
+	enabled := slices.Contains(cfg.Failover.Commands, cmd.Name())
+	if cmd.Flags().Changed("failover") {
+		enabled, _ = cmd.Flags().GetBool("failover")
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	if len(cfg.Failover.Profiles) == 0 {
+		return nil, i18n.Errorf("failover is on but profile %q has no failover profiles; set them with 'smsir config set failover.profiles <profile>,...'", cfg.Profile)
+	}
+	return cfg.Failover.Profiles, nil
+}
+
+// sendWithFailover sends text from line with the active profile. When the
+// account is out of credit or its key is rejected, message is signed for and
+// sent from the line of each failover profile in turn. Other errors stop the
+// chain, since the message may already have gone out. A non-zero sendAt
+// schedules the message on whichever profile sends it.
+func sendWithFailover(cmd *cobra.Command, client *api.Client, line config.Line, message, text string, mobiles, tags, chain []string, sendAt time.Time) (*delivery, error) {
+	resp, err := sendFromLine(client, cfg.Profile, line, text, mobiles, tags, sendAt)
+	if err == nil {
+		return &delivery{profile: cfg.Profile, line: line, resp: resp}, nil
+	}
+	if len(chain) == 0 || !api.IsAccountError(err) {
+		return nil, err
+	}
+
+	// A profile listed twice, or the active one listed again, is tried once
+	failed := cfg.Profile
+	tried := map[string]bool{cfg.Profile: true}
+	for _, name := range chain {
+		if tried[name] {
+			continue
+		}
+		tried[name] = true
+
+		fallback, fallbackLine, prepErr := failoverProfile(cmd, name, sendAt)
+		if prepErr != nil {
+			notice("⚠️  Skipping failover profile %q: %v\n", name, prepErr)
+			continue
+		}
+
+		notice("↪️  Profile %q could not send (%v); failing over to profile %q\n", failed, err, name)
+		resp, err = sendFromLine(api.NewClient(fallback), name, fallbackLine, signFor(cmd, fallbackLine, message), mobiles, tags, sendAt)
+		if err == nil {
+			return &delivery{profile: name, line: fallbackLine, resp: resp}, nil
+		}
+		if !api.IsAccountError(err) {
+			return nil, i18n.Errorf("profile %q: %w", name, err)
+		}
+		failed = name
+	}
+
+	return nil, i18n.Errorf("no profile could send, last error from profile %q: %w", failed, err)
+}
+
+// failoverProfile loads a failover profile and the line it sends from, checking
+// the line's send hours for sendAt
+func failoverProfile(cmd *cobra.Command, name string, sendAt time.Time) (*config.Config, config.Line, error) {
+	fallback, err := config.LoadProfile(name)
+	if err != nil {
+		return nil, config.Line{}, err
+	}
+	if fallback.APIKey == "" {
+		return nil, config.Line{}, i18n.Errorf("no API key configured")
+	}
+
+	line, err := fallback.ResolveLine(fallback.LineNumber)
+	if err != nil {
+		return nil, config.Line{}, err
+	}
+	if err := checkHours(cmd, line, sendAt); err != nil {
+		return nil, config.Line{}, err
+	}
+	return fallback, line, nil
+}
@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/output"
+	"github.com/SaneiyanReza/smsir-cli/internal/ui"
+	"github.com/SaneiyanReza/smsir-cli/internal/watch"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch <packId>",
+	Short: "Watch delivery progress of a sent pack",
+	Long: `Poll the delivery report of a pack until every message is delivered or failed.
+
+The poll interval starts short and grows while nothing changes. The command exits
+with a non-zero code when the timeout passes or when the failure rate is above
+--max-failure-rate, which is off by default. Progress is shown in an interactive
+view on a terminal and as plain lines on stderr otherwise (or with --plain).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		packID := args[0]
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		minInterval, _ := cmd.Flags().GetDuration("interval")
+		maxInterval, _ := cmd.Flags().GetDuration("max-interval")
+		maxFailureRate, _ := cmd.Flags().GetFloat64("max-failure-rate")
+		plain, _ := cmd.Flags().GetBool("plain")
+
+		client := api.NewClient(cfg)
+		poller := watch.NewPoller(minInterval, maxInterval)
+
+		var summary api.DeliverySummary
+		var err error
+		if plain || !term.IsTerminal(int(os.Stdout.Fd())) {
+			summary, err = watchPlain(client, packID, poller, timeout)
+		} else {
+			summary, err = watchTUI(client, packID, poller, timeout)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = out.Render(output.Result{
+			Data:    watchResult{PackID: packID, DeliverySummary: summary, FailureRate: summary.FailureRate()},
+			Columns: []string{"PACK ID", "TOTAL", "DELIVERED", "FAILED", "PENDING"},
+			Rows: [][]string{{
+				packID,
+				strconv.Itoa(summary.Total),
+				strconv.Itoa(summary.Delivered),
+				strconv.Itoa(summary.Failed),
+				strconv.Itoa(summary.Pending),
+			}},
+			Text: func(w io.Writer) {
+				i18n.Fprintf(w, "📊 Delivered: %d, Failed: %d, Pending: %d, Total: %d\n",
+					summary.Delivered, summary.Failed, summary.Pending, summary.Total)
+			},
+			Values: []string{strconv.Itoa(summary.Delivered)},
+		})
+		if err != nil {
+			return err
+		}
+
+		if rate := summary.FailureRate(); rate > maxFailureRate {
+			return i18n.Errorf("failure rate %.1f%% exceeds threshold %.1f%%", rate*100, maxFailureRate*100)
+		}
+		return nil
+	},
+}
+
+// watchResult is the structured output of the watch command
+type watchResult struct {
+	PackID string `json:"packId"`
+	api.DeliverySummary
+	FailureRate float64 `json:"failureRate"`
+}
+
+func init() {
+	watchCmd.Flags().Duration("timeout", 30*time.Minute, "Give up after this long")
+	watchCmd.Flags().Duration("interval", watch.DefaultMinInterval, "Shortest time between polls")
+	watchCmd.Flags().Duration("max-interval", watch.DefaultMaxInterval, "Longest time between polls")
+	watchCmd.Flags().Float64("max-failure-rate", 1, "Exit with an error if more than this fraction of messages fail (0-1; the default 1 never fails)")
+	watchCmd.Flags().Bool("plain", false, "Print progress lines to stderr instead of the interactive view")
+}
+
+// watchPlain polls the pack report and prints a progress line to stderr after each poll
+func watchPlain(client *api.Client, packID string, poller *watch.Poller, timeout time.Duration) (api.DeliverySummary, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		resp, err := client.GetPackReport(packID)
+		if err != nil {
+			return api.DeliverySummary{}, i18n.Errorf("error getting pack report: %w", err)
+		}
+		if !resp.IsSuccess() {
+			return api.DeliverySummary{}, i18n.Errorf("API error: %s", resp.GetStatusMessage())
+		}
+
+		summary := resp.Data.Summary()
+		i18n.Fprintf(os.Stderr, "[%s] %s delivered=%d failed=%d pending=%d total=%d\n",
+			time.Now().Format("15:04:05"), plainBar(summary, 30),
+			summary.Delivered, summary.Failed, summary.Pending, summary.Total)
+
+		if summary.Done() {
+			return summary, nil
+		}
+
+		if !time.Now().Before(deadline) {
+			return summary, i18n.Errorf("timed out after %s with %d message(s) pending", timeout, summary.Pending)
+		}
+		// The last poll happens at the deadline rather than one interval before it
+		time.Sleep(min(poller.Next(summary), time.Until(deadline)))
+	}
+}
+
+// watchTUI runs the interactive watch view
+func watchTUI(client *api.Client, packID string, poller *watch.Poller, timeout time.Duration) (api.DeliverySummary, error) {
+	ui.LoadBidi()
+	model := ui.NewWatchModel(client, packID, poller, timeout)
+	finalModel, err := tea.NewProgram(model).Run()
+	if err != nil {
+		return api.DeliverySummary{}, err
+	}
+
+	result, ok := finalModel.(ui.WatchModel)
+	if !ok {
+		return api.DeliverySummary{}, i18n.Errorf("unexpected watch result")
+	}
+
+	summary := result.Summary()
+	switch {
+	case result.Err() != nil:
+		return summary, i18n.Errorf("error getting pack report: %w", result.Err())
+	case result.Cancelled():
+		return summary, i18n.Errorf("watch cancelled with %d message(s) pending", summary.Pending)
+	case result.TimedOut():
+		return summary, i18n.Errorf("timed out after %s with %d message(s) pending", timeout, summary.Pending)
+	}
+	return summary, nil
+}
+
+// plainBar renders a text progress bar of finished messages
+func plainBar(s api.DeliverySummary, width int) string {
+	done := 0
+	if s.Total > 0 {
+		done = width * (s.Delivered + s.Failed) / s.Total
+	}
+	return "[" + strings.Repeat("#", done) + strings.Repeat("-", width-done) + "]"
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+)
+
+// maxClockSkew is the largest difference from the server clock that passes the check
+const maxClockSkew = time.Minute
+
+// CheckResult is the outcome of a single online configuration check
+type CheckResult struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail"`
+	// Warning marks a failed check that does not make the configuration unusable
+	Warning bool `json:"warning,omitempty"`
+}
+
+// CheckConfig verifies the configuration against the live account: the base
+// URL must be reachable, the local clock close to the server's, the API key
+// accepted and the line number, or the line its alias names, one of the
+// account's lines.
+func (c *Client) CheckConfig() []CheckResult {
+	var results []CheckResult
+	add := func(name string, ok bool, format string, a ...interface{}) {
+		results = append(results, CheckResult{Name: name, OK: ok, Detail: i18n.Sprintf(format, a...)})
+	}
+	skip := func(names ...string) {
+		for _, name := range names {
+			add(name, false, "skipped")
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.doRequest("GET", "/credit", nil)
+	if err != nil {
+		add("base url", false, "%s is not reachable: %v", c.baseURL, err)
+		skip("clock", "api key", "line number")
+		return results
+	}
+	add("base url", true, "%s answered in %s", c.baseURL, time.Since(start).Round(time.Millisecond))
+
+	if serverTime, err := http.ParseTime(resp.Header.Get("Date")); err != nil {
+		add("clock", false, "server did not report its time")
+	} else {
+		skew := time.Since(serverTime).Round(time.Second)
+		switch {
+		case skew > maxClockSkew:
+			add("clock", false, "local clock is %s ahead of the server", skew)
+		case skew < -maxClockSkew:
+			add("clock", false, "local clock is %s behind the server", -skew)
+		default:
+			add("clock", true, "within %s of the server", maxClockSkew)
+		}
+	}
+	// A wrong clock only affects scheduling and report times
+	results[len(results)-1].Warning = !results[len(results)-1].OK
+
+	credit, err := parseResponse[CreditResponse](resp)
+	if err != nil {
+		add("api key", false, "%v", err)
+		skip("line number")
+		return results
+	}
+	add("api key", true, "accepted (credit %s)", i18n.Digits(strconv.FormatFloat(float64(credit.Data), 'f', -1, 64)))
+
+	lines, err := c.GetLines()
+	if err != nil {
+		add("line number", false, "could not list lines: %v", err)
+		return results
+	}
+	available := make([]string, len(lines.Data))
+	for i, line := range lines.Data {
+		available[i] = strconv.FormatInt(line, 10)
+	}
+	if c.config.LineNumber == "" {
+		add("line number", false, "not set (available: %s)", strings.Join(available, ", "))
+		return results
+	}
+
+	// The line number may be an alias of a configured line
+	line, err := c.config.ResolveLine(c.config.LineNumber)
+	if err != nil {
+		add("line number", false, "%v", err)
+		return results
+	}
+	for _, number := range available {
+		if number == line.Number {
+			add("line number", true, "%s belongs to the account", line.Label())
+			return results
+		}
+	}
+	add("line number", false, "%s is not one of the account's lines (available: %s)", line.Label(), strings.Join(available, ", "))
+	return results
+}
+
+// ChecksPassed reports whether every check passed, ignoring warnings
+func ChecksPassed(results []CheckResult) bool {
+	for _, r := range results {
+		if !r.OK && !r.Warning {
+			return false
+		}
+	}
+	return true
+}
@@ -3,12 +3,14 @@ package api
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
 )
 
 const (
@@ -36,20 +38,20 @@ func NewClient(cfg *config.Config) *Client {
 
 // doRequest performs an HTTP request to the API
 func (c *Client) doRequest(method, endpoint string, body interface{}) (*http.Response, error) {
-	url := c.baseURL + endpoint
+	reqURL := c.baseURL + endpoint
 
 	var reqBody io.Reader
 	if body != nil {
 		jsonData, err := json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, i18n.Errorf("failed to marshal request body: %w", err)
 		}
 		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequest(method, reqURL, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, i18n.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
@@ -59,7 +61,7 @@ func (c *Client) doRequest(method, endpoint string, body interface{}) (*http.Res
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to perform request: %w", err)
+		return nil, i18n.Errorf("failed to perform request: %w", err)
 	}
 
 	return resp, nil
@@ -76,12 +78,12 @@ func parseResponse[T any](resp *http.Response) (*APIResponse[T], error) {
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, i18n.Errorf("failed to read response body: %w", err)
 	}
 
 	var apiResp APIResponse[T]
 	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, i18n.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return &apiResp, nil
@@ -114,20 +116,71 @@ func (c *Client) SendBulk(req BulkSendRequest) (*APIResponse[BulkSendResponse],
 	return parseResponse[BulkSendResponse](resp)
 }
 
-// HandleAPIError handles API errors based on status codes
+// GetMessageReport retrieves the delivery report of a single message
+func (c *Client) GetMessageReport(messageID int32) (*APIResponse[ReportSendMessageResponse], error) {
+	resp, err := c.doRequest("GET", "/send/"+strconv.FormatInt(int64(messageID), 10), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseResponse[ReportSendMessageResponse](resp)
+}
+
+// GetPackReport retrieves the delivery report of every message in a pack
+func (c *Client) GetPackReport(packID string) (*APIResponse[ReportSendPackResponse], error) {
+	resp, err := c.doRequest("GET", "/send/pack/"+url.PathEscape(packID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseResponse[ReportSendPackResponse](resp)
+}
+
+// GetLiveReceived retrieves received messages that have not been fetched yet
+func (c *Client) GetLiveReceived(pageNumber, pageSize int) (*APIResponse[ReceiveResponse], error) {
+	query := url.Values{}
+	query.Set("pageNumber", strconv.Itoa(pageNumber))
+	query.Set("pageSize", strconv.Itoa(pageSize))
+	query.Set("sortByNewest", "false")
+
+	resp, err := c.doRequest("GET", "/receive/live?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseResponse[ReceiveResponse](resp)
+}
+
+// GetReceivedArchive retrieves received messages between two unix timestamps
+func (c *Client) GetReceivedArchive(pageNumber, pageSize int, fromDate, toDate int64) (*APIResponse[ReceiveResponse], error) {
+	query := url.Values{}
+	query.Set("pageNumber", strconv.Itoa(pageNumber))
+	query.Set("pageSize", strconv.Itoa(pageSize))
+	if fromDate > 0 {
+		query.Set("fromDate", strconv.FormatInt(fromDate, 10))
+	}
+	if toDate > 0 {
+		query.Set("toDate", strconv.FormatInt(toDate, 10))
+	}
+
+	resp, err := c.doRequest("GET", "/receive/archive?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseResponse[ReceiveResponse](resp)
+}
+
+// GetLatestReceived retrieves the most recent received messages
+func (c *Client) GetLatestReceived(count int) (*APIResponse[ReceiveResponse], error) {
+	resp, err := c.doRequest("GET", "/receive/latest?count="+strconv.Itoa(count), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseResponse[ReceiveResponse](resp)
+}
+
+// HandleAPIError handles API errors based on status codes. Errors are
+// *APIError values carrying the status code from the response body.
 func HandleAPIError(resp *http.Response) error {
-	switch resp.StatusCode {
-	case 200:
+	if resp.StatusCode == http.StatusOK {
 		return nil
-	case 400:
-		return fmt.Errorf("logical error: invalid request")
-	case 401:
-		return fmt.Errorf("authentication error: invalid API key")
-	case 429:
-		return fmt.Errorf("rate limit exceeded: please wait a moment")
-	case 500:
-		return fmt.Errorf("server error: unexpected error")
-	default:
-		return fmt.Errorf("unknown error with status code: %d", resp.StatusCode)
 	}
+	return newAPIError(resp)
 }
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+)
+
+// Status codes returned by SMS.ir in the status field of a response
+const (
+	StatusServerError        = 0
+	StatusSuccess            = 1
+	StatusInvalidAPIKey      = 10
+	StatusAPIKeyDisabled     = 11
+	StatusIPNotAllowed       = 12
+	StatusAccountInactive    = 13
+	StatusAccountSuspended   = 14
+	StatusTooManyRequests    = 20
+	StatusInvalidLine        = 101
+	StatusInsufficientCredit = 102
+)
+
+// statusMessages describes the status codes
+var statusMessages = map[int]string{
+	StatusServerError:        "Failed",
+	StatusSuccess:            "Success",
+	StatusInvalidAPIKey:      "invalid API key",
+	StatusAPIKeyDisabled:     "API key is disabled",
+	StatusIPNotAllowed:       "API key is not allowed from this IP address",
+	StatusAccountInactive:    "account is inactive",
+	StatusAccountSuspended:   "account is suspended",
+	StatusTooManyRequests:    "too many requests",
+	StatusInvalidLine:        "invalid line number",
+	StatusInsufficientCredit: "insufficient credit",
+}
+
+// APIError is an error response from the API
+type APIError struct {
+	// HTTPStatus is the HTTP status code of the response
+	HTTPStatus int
+	// Status is the status field of the response body, or -1 if it had none
+	Status  int
+	Message string
+}
+
+// Error returns the error message
+func (e *APIError) Error() string {
+	if msg, ok := statusMessages[e.Status]; ok && e.Status > StatusSuccess {
+		return i18n.Sprintf("API error: %s", i18n.T(msg))
+	}
+
+	switch e.HTTPStatus {
+	case http.StatusBadRequest:
+		return i18n.T("logical error: invalid request")
+	case http.StatusUnauthorized:
+		return i18n.T("authentication error: invalid API key")
+	case http.StatusTooManyRequests:
+		return i18n.T("rate limit exceeded: please wait a moment")
+	case http.StatusInternalServerError:
+		return i18n.T("server error: unexpected error")
+	case http.StatusOK:
+		return i18n.Sprintf("API error: %s", statusMessage(e.Status))
+	default:
+		return i18n.Sprintf("unknown error with status code: %d", e.HTTPStatus)
+	}
+}
+
+// IsAuth reports whether the account's key was rejected, e.g. invalid, disabled or suspended
+func (e *APIError) IsAuth() bool {
+	if e.HTTPStatus == http.StatusUnauthorized {
+		return true
+	}
+	return e.Status >= StatusInvalidAPIKey && e.Status <= StatusAccountSuspended
+}
+
+// IsInsufficientCredit reports whether the account does not have enough credit
+func (e *APIError) IsInsufficientCredit() bool {
+	return e.Status == StatusInsufficientCredit
+}
+
+// IsAccountError reports whether err means the account itself cannot send,
+// because its key was rejected or it is out of credit. Another account may
+// still succeed where this one failed.
+func IsAccountError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.IsAuth() || apiErr.IsInsufficientCredit()
+}
+
+// statusMessage returns the description of a status code in the current language
+func statusMessage(status int) string {
+	if message, exists := statusMessages[status]; exists {
+		return i18n.T(message)
+	}
+	return i18n.Sprintf("Unknown status: %d", status)
+}
+
+// newAPIError reads the status of an error response from its body, if it has one
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{HTTPStatus: resp.StatusCode, Status: -1}
+
+	var body struct {
+		Status  *int   `json:"status"`
+		Message string `json:"message"`
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err == nil && json.Unmarshal(data, &body) == nil && body.Status != nil {
+		apiErr.Status = *body.Status
+		apiErr.Message = body.Message
+	}
+	return apiErr
+}
@@ -1,6 +1,9 @@
 package api
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+)
 
 // API Response Structure matching SMS.ir API
 type APIResponse[T any] struct {
@@ -11,20 +14,20 @@ type APIResponse[T any] struct {
 
 // GetStatusMessage returns the status message
 func (r *APIResponse[T]) GetStatusMessage() string {
-	statusMessages := map[int]string{
-		0: "Failed",
-		1: "Success",
-	}
-
-	if message, exists := statusMessages[r.Status]; exists {
-		return message
-	}
-	return fmt.Sprintf("Unknown status: %d", r.Status)
+	return statusMessage(r.Status)
 }
 
 // IsSuccess checks if the API response indicates success
 func (r *APIResponse[T]) IsSuccess() bool {
-	return r.Status == 1
+	return r.Status == StatusSuccess
+}
+
+// Err returns the response's status as an *APIError, or nil on success
+func (r *APIResponse[T]) Err() error {
+	if r.IsSuccess() {
+		return nil
+	}
+	return &APIError{HTTPStatus: http.StatusOK, Status: r.Status, Message: r.Message}
 }
 
 // CreditResponse for GET /v1/credit
@@ -50,15 +53,101 @@ type BulkSendResponse struct {
 
 // SendMessageReport for GET /v1/send/{messageId}
 type ReportSendMessageResponse struct {
-	MessageId        int32   `json:"messageId"`
-	Mobile           int64   `json:"mobile"`
-	MessageText      string  `json:"messageText"`
-	SendDateTime     int64   `json:"sendDateTime"`
-	LineNumber       int64   `json:"lineNumber"`
-	Cost             float64 `json:"cost"`
-	DeliveryState    int     `json:"deliveryState"`
-	DeliveryDateTime int64   `json:"deliveryDateTime"`
-	Status           string  `json:"status"`
+	MessageId        int32         `json:"messageId"`
+	Mobile           int64         `json:"mobile"`
+	MessageText      string        `json:"messageText"`
+	SendDateTime     int64         `json:"sendDateTime"`
+	LineNumber       int64         `json:"lineNumber"`
+	Cost             float64       `json:"cost"`
+	DeliveryState    DeliveryState `json:"deliveryState"`
+	DeliveryDateTime int64         `json:"deliveryDateTime"`
+	Status           string        `json:"status"`
+}
+
+// DeliveryState is the delivery status of a single sent message
+type DeliveryState int
+
+// Delivery states reported by SMS.ir
+const (
+	DeliveryStatePending       DeliveryState = 0
+	DeliveryStateDelivered     DeliveryState = 1 // Delivered to handset
+	DeliveryStateUndelivered   DeliveryState = 2 // Not delivered to handset
+	DeliveryStateInOperator    DeliveryState = 3 // Being processed by the operator
+	DeliveryStateNotInOperator DeliveryState = 4 // Not delivered to the operator
+	DeliveryStateAtOperator    DeliveryState = 5 // Delivered to the operator, waiting for handset
+	DeliveryStateFailed        DeliveryState = 6 // Failed to send
+	DeliveryStateBlacklisted   DeliveryState = 7 // Recipient is on the operator blacklist
+)
+
+// IsFinal reports whether the state will not change any more
+func (s DeliveryState) IsFinal() bool {
+	return s == DeliveryStateDelivered || s.IsFailed()
+}
+
+// IsFailed reports whether the message will never be delivered
+func (s DeliveryState) IsFailed() bool {
+	switch s {
+	case DeliveryStateUndelivered, DeliveryStateNotInOperator, DeliveryStateFailed, DeliveryStateBlacklisted:
+		return true
+	}
+	return false
+}
+
+// String returns a human readable delivery state
+func (s DeliveryState) String() string {
+	switch s {
+	case DeliveryStatePending:
+		return "Pending"
+	case DeliveryStateDelivered:
+		return "Delivered"
+	case DeliveryStateUndelivered:
+		return "Undelivered"
+	case DeliveryStateInOperator:
+		return "In operator"
+	case DeliveryStateNotInOperator:
+		return "Not sent to operator"
+	case DeliveryStateAtOperator:
+		return "At operator"
+	case DeliveryStateFailed:
+		return "Failed"
+	case DeliveryStateBlacklisted:
+		return "Blacklisted"
+	}
+	return fmt.Sprintf("Unknown (%d)", int(s))
+}
+
+// DeliverySummary counts messages of a pack by delivery outcome
+type DeliverySummary struct {
+	Total     int `json:"total"`
+	Delivered int `json:"delivered"`
+	Failed    int `json:"failed"`
+	Pending   int `json:"pending"`
+}
+
+// Count adds one message in the given state
+func (s *DeliverySummary) Count(state DeliveryState) {
+	s.Total++
+	switch {
+	case state == DeliveryStateDelivered:
+		s.Delivered++
+	case state.IsFailed():
+		s.Failed++
+	default:
+		s.Pending++
+	}
+}
+
+// Done reports whether every message has reached a final state
+func (s DeliverySummary) Done() bool {
+	return s.Total > 0 && s.Pending == 0
+}
+
+// FailureRate returns the fraction of failed messages
+func (s DeliverySummary) FailureRate() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Failed) / float64(s.Total)
 }
 
 // SendPackReport for GET /v1/send/pack/{packId}
@@ -70,6 +159,40 @@ type ReportSendPackResponse struct {
 	Messages    []ReportSendMessageResponse `json:"messages"`
 }
 
+// ReceivedMessage is a single inbound message from the receive endpoints
+type ReceivedMessage struct {
+	Number           int64  `json:"number"`
+	MessageText      string `json:"messageText"`
+	Mobile           int64  `json:"mobile"`
+	ReceivedDateTime int64  `json:"receivedDateTime"`
+}
+
+// ReceiveResponse for GET /v1/receive/live, /v1/receive/archive and /v1/receive/latest
+type ReceiveResponse []ReceivedMessage
+
+// Summary counts the pack's messages by delivery outcome
+func (r *ReportSendPackResponse) Summary() DeliverySummary {
+	var s DeliverySummary
+	for _, msg := range r.Messages {
+		switch {
+		case msg.DeliveryState == DeliveryStateDelivered:
+			s.Delivered++
+		case msg.DeliveryState.IsFailed():
+			s.Failed++
+		default:
+			s.Pending++
+		}
+	}
+	s.Total = len(r.Messages)
+
+	// Messages may not be listed yet right after sending
+	if total := int(r.TotalCount); total > s.Total {
+		s.Pending += total - s.Total
+		s.Total = total
+	}
+	return s
+}
+
 // RemoveScheduledResponse for DELETE /v1/send/scheduled/{packId}
 type RemoveScheduledResponse struct {
 	ReturnedCreditCount float64 `json:"returnedCreditCount"`
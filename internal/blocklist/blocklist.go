@@ -0,0 +1,151 @@
+package blocklist
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/phone"
+)
+
+const (
+	// blocklistFileName is the name of the local blocklist file
+	blocklistFileName = "blocklist.json"
+	// defaultFilePerms are the default permissions for the blocklist file
+	defaultFilePerms = 0600
+)
+
+// Entry is a single suppressed mobile number
+type Entry struct {
+	Mobile  string    `json:"mobile"`
+	Reason  string    `json:"reason"`
+	Source  string    `json:"source"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// Blocklist is the local suppression list of numbers that must not be messaged
+type Blocklist struct {
+	path    string
+	entries map[string]Entry
+}
+
+// Load reads the blocklist from the configuration directory
+func Load() (*Blocklist, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Blocklist{
+		path:    filepath.Join(dir, blocklistFileName),
+		entries: make(map[string]Entry),
+	}
+
+	data, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("failed to read blocklist: %w", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, i18n.Errorf("failed to unmarshal blocklist: %w", err)
+	}
+	for _, e := range entries {
+		b.entries[e.Mobile] = e
+	}
+
+	return b, nil
+}
+
+// Add puts a number on the blocklist and reports whether it was newly added
+func (b *Blocklist) Add(mobile, reason, source string) (bool, error) {
+	number, err := phone.Normalize(mobile)
+	if err != nil {
+		return false, err
+	}
+
+	if _, exists := b.entries[number]; exists {
+		return false, nil
+	}
+
+	b.entries[number] = Entry{
+		Mobile:  number,
+		Reason:  reason,
+		Source:  source,
+		AddedAt: time.Now(),
+	}
+	return true, nil
+}
+
+// Remove takes a number off the blocklist and reports whether it was present
+func (b *Blocklist) Remove(mobile string) bool {
+	number, err := phone.Normalize(mobile)
+	if err != nil {
+		return false
+	}
+
+	if _, exists := b.entries[number]; !exists {
+		return false
+	}
+	delete(b.entries, number)
+	return true
+}
+
+// Contains reports whether a number is on the blocklist
+func (b *Blocklist) Contains(mobile string) bool {
+	number, err := phone.Normalize(mobile)
+	if err != nil {
+		return false
+	}
+	_, exists := b.entries[number]
+	return exists
+}
+
+// Filter splits mobiles into allowed and blocked numbers
+func (b *Blocklist) Filter(mobiles []string) (allowed, blocked []string) {
+	for _, mobile := range mobiles {
+		if b.Contains(mobile) {
+			blocked = append(blocked, mobile)
+		} else {
+			allowed = append(allowed, mobile)
+		}
+	}
+	return allowed, blocked
+}
+
+// Entries returns all entries sorted by the time they were added
+func (b *Blocklist) Entries() []Entry {
+	entries := make([]Entry, 0, len(b.entries))
+	for _, e := range b.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].AddedAt.Before(entries[j].AddedAt)
+	})
+	return entries
+}
+
+// Save writes the blocklist to disk
+func (b *Blocklist) Save() error {
+	if config.ReadOnly() {
+		return config.ErrReadOnly
+	}
+
+	data, err := json.MarshalIndent(b.Entries(), "", "  ")
+	if err != nil {
+		return i18n.Errorf("failed to marshal blocklist: %w", err)
+	}
+
+	if err := os.WriteFile(b.path, data, defaultFilePerms); err != nil {
+		return i18n.Errorf("failed to write blocklist: %w", err)
+	}
+
+	return nil
+}
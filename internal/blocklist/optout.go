@@ -0,0 +1,51 @@
+package blocklist
+
+import (
+	"strings"
+	"unicode"
+)
+
+// IsOptOut reports whether a reply asks to stop receiving messages.
+// The first word of the reply must match one of the keywords; trailing digits
+// (as in "لغو11") and punctuation are ignored, and Arabic/Persian letter
+// variants are treated as equal.
+func IsOptOut(text string, keywords []string) bool {
+	fields := strings.Fields(normalizeKeyword(text))
+	if len(fields) == 0 {
+		return false
+	}
+	first := strings.TrimRightFunc(fields[0], unicode.IsDigit)
+
+	for _, keyword := range keywords {
+		k := normalizeKeyword(keyword)
+		if k != "" && first == k {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeKeyword lower-cases text, unifies Arabic and Persian letters and digits,
+// and strips zero-width characters and punctuation
+func normalizeKeyword(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == 'ي' || r == 'ى':
+			b.WriteRune('ی')
+		case r == 'ك':
+			b.WriteRune('ک')
+		case r >= '۰' && r <= '۹':
+			b.WriteRune('0' + (r - '۰'))
+		case r >= '٠' && r <= '٩':
+			b.WriteRune('0' + (r - '٠'))
+		case r == '‌' || r == '‍' || r == '‏' || r == '‎':
+			// Zero-width joiners and direction marks are dropped
+		case unicode.IsPunct(r):
+			b.WriteRune(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
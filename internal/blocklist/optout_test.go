@@ -0,0 +1,54 @@
+package blocklist
+
+import "testing"
+
+func TestIsOptOut(t *testing.T) {
+	keywords := []string{"لغو", "انصراف", "STOP"}
+
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{name: "keyword", text: "لغو", want: true},
+		{name: "latin keyword in another case", text: "stop", want: true},
+		{name: "trailing digits", text: "لغو11", want: true},
+		{name: "trailing persian digits", text: "لغو۱۱", want: true},
+		{name: "punctuation", text: "STOP!", want: true},
+		{name: "more words after the keyword", text: "انصراف لطفا", want: true},
+		{name: "zero-width non-joiner", text: "ل‌غو", want: true},
+		{name: "keyword not first", text: "لطفا لغو", want: false},
+		{name: "keyword inside a word", text: "stopped", want: false},
+		{name: "other reply", text: "ممنون", want: false},
+		{name: "empty", text: "", want: false},
+		{name: "only spaces", text: "   ", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsOptOut(tt.text, keywords); got != tt.want {
+				t.Errorf("IsOptOut(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsOptOutLetterVariants(t *testing.T) {
+	// Keywords typed with Persian letters match replies typed with Arabic ones
+	tests := []struct {
+		text     string
+		keywords []string
+		want     bool
+	}{
+		{"كنسل", []string{"کنسل"}, true},
+		{"لغوي", []string{"لغوی"}, true},
+		{"لغو", nil, false},
+		{"لغو", []string{""}, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsOptOut(tt.text, tt.keywords); got != tt.want {
+			t.Errorf("IsOptOut(%q, %q) = %v, want %v", tt.text, tt.keywords, got, tt.want)
+		}
+	}
+}
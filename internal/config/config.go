@@ -2,136 +2,404 @@ package config
 
 import (
 	"encoding/json"
-	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 
-	"github.com/spf13/viper"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
 )
 
 const (
-	// configDirName is the name of the configuration directory
+	// configDirName is the name of the configuration directory in the home directory
 	configDirName = ".smsir"
+	// xdgDirName is the name of the configuration directory under XDG_CONFIG_HOME
+	xdgDirName = "smsir"
 	// configFileName is the name of the configuration file
 	configFileName = "config.json"
 	// defaultConfigPerms are the default permissions for config directory
-	defaultConfigPerms = 0755
+	defaultConfigPerms = 0700
 	// defaultFilePerms are the default permissions for config file
-	defaultFilePerms = 0644
+	defaultFilePerms = 0600
 	// defaultBaseURL is the default SMS.ir API base URL
 	defaultBaseURL = "https://api.sms.ir/v1"
+	// defaultDashboardRefresh is the default dashboard auto-refresh interval in seconds
+	defaultDashboardRefresh = 60
+	// defaultDashboardRecent is the default number of recent sends on the dashboard
+	defaultDashboardRecent = 5
 )
 
-// Config holds the application configuration
+// Config holds the application configuration of a single profile
 type Config struct {
-	APIKey     string `json:"api_key" mapstructure:"api_key"`
-	LineNumber string `json:"line_number" mapstructure:"line_number"`
-	BaseURL    string `json:"base_url" mapstructure:"base_url"`
+	// APIKey is only kept in memory; the file stores APIKeyRef, a reference
+	// into the secret backend. Plaintext keys of older files are moved on save.
+	//
+	// Keys for config get/set are derived from the json tags; desc describes a
+	// key, secret masks its value and config:"-" hides it.
+	APIKey     string          `json:"api_key,omitempty" mapstructure:"api_key" desc:"API key from the SMS.ir panel" secret:"true"`
+	APIKeyRef  string          `json:"api_key_ref,omitempty" mapstructure:"api_key_ref" config:"-"`
+	LineNumber string          `json:"line_number" mapstructure:"line_number" desc:"Sender line number or line alias"`
+	BaseURL    string          `json:"base_url" mapstructure:"base_url" desc:"SMS.ir API base URL"`
+	OptOut     OptOutConfig    `json:"optout" mapstructure:"optout"`
+	Failover   FailoverConfig  `json:"failover" mapstructure:"failover"`
+	Dashboard  DashboardConfig `json:"dashboard" mapstructure:"dashboard"`
+	// Lines are named sender lines; line_number and --line accept their names
+	Lines map[string]Line `json:"lines,omitempty" mapstructure:"lines" desc:"Named sender lines as a JSON object; see 'smsir lines alias'"`
+
+	// Profile is the name this configuration was loaded from; it is not stored in the file
+	Profile string `json:"-" mapstructure:"-"`
+
+	// apiKeyFromEnv marks an API key taken from SMSIR_API_KEY, which must not be persisted
+	apiKeyFromEnv bool
+	// stored is the profile as read from the file before the project and
+	// environment layers were applied; SaveConfig writes its values back for
+	// every key that was not changed, so those layers stay out of the user file
+	stored *Config
+	// changed records the keys changed with Set or Unset since loading
+	changed map[string]bool
+	// sources records where each value came from, see Source
+	sources map[string]string
+}
+
+// Sources a configuration value can come from
+const (
+	SourceDefault = "default"
+	SourceFile    = "config file"
+	SourceProject = "project file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+	SourceSecret  = "secret"
+)
+
+// Source returns where a value was loaded from, e.g. "env SMSIR_LINE_NUMBER".
+// Keys are "profile" and the names returned by Keys.
+func (c *Config) Source(key string) string {
+	if source, ok := c.sources[key]; ok {
+		return source
+	}
+	return SourceDefault
+}
+
+// setSource records where a value was loaded from
+func (c *Config) setSource(key, source string) {
+	if c.sources == nil {
+		c.sources = make(map[string]string)
+	}
+	c.sources[key] = source
+}
+
+// OptOutConfig holds the settings used to process opt-out replies
+type OptOutConfig struct {
+	Keywords       []string `json:"keywords" yaml:"keywords" mapstructure:"keywords" desc:"Reply keywords that opt a number out, comma separated"`
+	ConfirmMessage string   `json:"confirm_message" yaml:"confirm_message" mapstructure:"confirm_message" desc:"Message sent to confirm an opt-out (empty to send none)"`
+}
+
+// FailoverConfig holds the profiles a send falls back to when this profile
+// is out of credit or its API key is rejected
+type FailoverConfig struct {
+	Profiles []string `json:"profiles" yaml:"profiles" mapstructure:"profiles" desc:"Profiles to send from, in order, when this one is out of credit or its key is rejected"`
+	Commands []string `json:"commands" yaml:"commands" mapstructure:"commands" desc:"Commands that fail over without --failover, e.g. send"`
 }
 
+// DashboardConfig holds the settings of the interactive dashboard
+type DashboardConfig struct {
+	Refresh   int `json:"refresh" yaml:"refresh" mapstructure:"refresh" desc:"Dashboard auto-refresh interval in seconds (0 to refresh only with r)"`
+	LowCredit int `json:"low_credit" yaml:"low_credit" mapstructure:"low_credit" desc:"Warn on the dashboard when credit drops below this many SMS (0 to never warn)"`
+	Recent    int `json:"recent" yaml:"recent" mapstructure:"recent" desc:"Number of recent sends listed on the dashboard"`
+}
+
+// DefaultOptOutKeywords are the reply keywords that opt a number out
+var DefaultOptOutKeywords = []string{"لغو", "انصراف", "STOP", "UNSUBSCRIBE", "CANCEL"}
+
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
+	// Keywords is copied so decoding a profile into it cannot change the package default
 	return &Config{
 		BaseURL: defaultBaseURL,
+		OptOut: OptOutConfig{
+			Keywords: append([]string(nil), DefaultOptOutKeywords...),
+		},
+		Dashboard: DashboardConfig{
+			Refresh: defaultDashboardRefresh,
+			Recent:  defaultDashboardRecent,
+		},
 	}
 }
 
-// LoadConfig loads configuration from file and environment variables
+// LoadConfig loads the active profile and applies the configuration layers.
+// Values are taken from, in order of precedence: command flags (applied by
+// the commands themselves), SMSIR_* environment variables, the project file
+// (.smsir.yaml or .smsir.json) and the user configuration file.
+// The active profile is chosen by SetProfile (the --profile flag), then the
+// SMSIR_PROFILE environment variable, then the project file, then the user
+// file's default profile.
 func LoadConfig() (*Config, error) {
-	cfg := DefaultConfig()
-
-	// Set config file path
-	configFile, err := getConfigPath()
+	f, err := LoadFile()
 	if err != nil {
 		return nil, err
 	}
+	if f.projectErr != nil {
+		return nil, f.projectErr
+	}
 
-	// Check if config file exists
-	if _, err := os.Stat(configFile); os.IsNotExist(err) {
-		// Config file doesn't exist, create default one
-		if err := createDefaultConfig(configFile); err != nil {
-			return nil, fmt.Errorf("failed to create default config: %w", err)
+	name, profileSource := f.activeProfile()
+	cfg, ok := f.Profiles[name]
+	if !ok {
+		if len(f.Profiles) > 0 {
+			return nil, i18n.Errorf("profile %q not found", name)
 		}
+		// A fresh installation or read-only mode without a file has no profiles yet
+		cfg = DefaultConfig()
 	}
+	cfg.Profile = name
 
-	// Set viper configuration
-	viper.SetConfigFile(configFile)
-	viper.SetConfigType("json")
-	viper.SetEnvPrefix("SMSIR")
-	viper.AutomaticEnv()
+	cfg.setSource("profile", profileSource)
+	if ok {
+		for _, key := range Keys() {
+			cfg.setSource(key.Name, SourceFile)
+		}
+		delete(cfg.sources, "api_key")
+		if cfg.APIKeyRef != "" {
+			cfg.setSource("api_key", SourceSecret+" "+cfg.APIKeyRef)
+		} else if cfg.APIKey != "" {
+			cfg.setSource("api_key", SourceFile+" (plaintext)")
+		}
+	}
 
-	// Set default values
-	viper.SetDefault("base_url", defaultBaseURL)
+	stored := *cfg
+	f.project.apply(cfg)
+	applyEnv(cfg)
+	cfg.stored = &stored
 
-	// Read config file
-	if err := viper.ReadInConfig(); err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+	if cfg.APIKey == "" && cfg.APIKeyRef != "" {
+		key, err := resolveSecret(cfg.APIKeyRef)
+		if err != nil {
+			return nil, i18n.Errorf("failed to read API key (%s): %w", cfg.APIKeyRef, err)
+		}
+		cfg.APIKey = key
 	}
 
-	// Unmarshal config
-	if err := viper.Unmarshal(cfg); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	return cfg, nil
+}
+
+// applyEnv overrides configuration values with SMSIR_* environment variables
+func applyEnv(cfg *Config) {
+	if v := os.Getenv(apiKeyEnv); v != "" {
+		cfg.APIKey = v
+		cfg.apiKeyFromEnv = true
+		cfg.setSource("api_key", SourceEnv+" "+apiKeyEnv)
 	}
+	if v := os.Getenv("SMSIR_LINE_NUMBER"); v != "" {
+		cfg.LineNumber = v
+		cfg.setSource("line_number", SourceEnv+" SMSIR_LINE_NUMBER")
+	}
+	if v := os.Getenv("SMSIR_BASE_URL"); v != "" {
+		cfg.BaseURL = v
+		cfg.setSource("base_url", SourceEnv+" SMSIR_BASE_URL")
+	}
+}
 
-	return cfg, nil
+// configPath is the configuration file chosen with SetConfigPath, e.g. from --config
+var configPath string
+
+// SetConfigPath selects the configuration file for this process instead of
+// SMSIR_CONFIG or the file in the configuration directory
+func SetConfigPath(path string) {
+	configPath = path
+	// Whether smsir may write depends on where the file is
+	readOnly.checked = false
 }
 
 // getConfigPath returns the path to the configuration file
 func getConfigPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	path := configPath
+	if path == "" {
+		path = os.Getenv("SMSIR_CONFIG")
+	}
+	if path != "" {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return "", i18n.Errorf("failed to resolve config path: %w", err)
+		}
+		return abs, nil
+	}
+
+	configDir, err := dirPath()
 	if err != nil {
-		return "", fmt.Errorf("failed to get user home directory: %w", err)
+		return "", err
 	}
 
-	configDir := filepath.Join(homeDir, configDirName)
-	configFile := filepath.Join(configDir, configFileName)
+	return filepath.Join(configDir, configFileName), nil
+}
+
+// Dir returns the configuration directory, creating it if needed unless in
+// read-only mode. Local state such as the blocklist is stored next to the config file.
+func Dir() (string, error) {
+	configDir, err := dirPath()
+	if err != nil {
+		return "", err
+	}
+
+	if ReadOnly() {
+		return configDir, nil
+	}
 
 	// Create config directory if it doesn't exist
 	if err := os.MkdirAll(configDir, defaultConfigPerms); err != nil {
-		return "", fmt.Errorf("failed to create config directory: %w", err)
+		return "", i18n.Errorf("failed to create config directory: %w", err)
 	}
 
-	return configFile, nil
+	return configDir, nil
 }
 
-// SaveConfig saves the configuration to file
+// dirPath returns the configuration directory without creating it.
+// An existing $XDG_CONFIG_HOME/smsir is preferred, then an existing ~/.smsir;
+// new installations use $XDG_CONFIG_HOME/smsir when XDG_CONFIG_HOME is set
+// and ~/.smsir otherwise.
+func dirPath() (string, error) {
+	var xdgDir string
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		xdgDir = filepath.Join(xdg, xdgDirName)
+		if _, err := os.Stat(xdgDir); err == nil {
+			return xdgDir, nil
+		}
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", i18n.Errorf("failed to get user home directory: %w", err)
+	}
+
+	homeConfigDir := filepath.Join(homeDir, configDirName)
+	if _, err := os.Stat(homeConfigDir); err != nil && xdgDir != "" {
+		return xdgDir, nil
+	}
+	return homeConfigDir, nil
+}
+
+// SaveConfig saves the configuration into its profile, keeping all other profiles
 func (c *Config) SaveConfig() error {
-	configFile, err := getConfigPath()
+	f, err := LoadFile()
 	if err != nil {
 		return err
 	}
 
-	// Marshal config to JSON
-	data, err := json.MarshalIndent(c, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+	name := c.Profile
+	if name == "" {
+		name = f.ActiveProfile()
 	}
+	c.Profile = name
 
-	// Write to file
-	if err := os.WriteFile(configFile, data, defaultFilePerms); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
+	// Values the project file or environment set are not the user's to keep;
+	// unless they were changed, write the file's own values back instead
+	toSave := *c
+	if c.stored != nil {
+		if !c.changed["line_number"] {
+			toSave.LineNumber = c.stored.LineNumber
+		}
+		if !c.changed["base_url"] {
+			toSave.BaseURL = c.stored.BaseURL
+		}
+		if !c.changed["optout.keywords"] {
+			toSave.OptOut.Keywords = c.stored.OptOut.Keywords
+		}
+		if !c.changed["optout.confirm_message"] {
+			toSave.OptOut.ConfirmMessage = c.stored.OptOut.ConfirmMessage
+		}
+		if !c.changed["lines"] {
+			toSave.Lines = c.stored.Lines
+		}
+	}
+
+	f.Profiles[name] = &toSave
+	if f.DefaultProfile == "" {
+		f.DefaultProfile = name
 	}
 
+	if err := f.Save(); err != nil {
+		return err
+	}
+	c.APIKeyRef = toSave.APIKeyRef
 	return nil
 }
 
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
 	if c.APIKey == "" {
-		return fmt.Errorf("api key is required")
+		return i18n.Errorf("api key is required")
 	}
 	if c.LineNumber == "" {
-		return fmt.Errorf("line number is required")
+		return i18n.Errorf("line number is required")
 	}
-	return nil
+	return c.ValidateValues()
+}
+
+// ValidateValues checks the values that are set without requiring the
+// API key and line number, e.g. after config set or config edit
+func (c *Config) ValidateValues() error {
+	if c.BaseURL != "" {
+		u, err := url.Parse(c.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return i18n.Errorf("base_url must be an http or https URL")
+		}
+	}
+	if c.Dashboard.Refresh < 0 {
+		return i18n.Errorf("dashboard.refresh must not be negative")
+	}
+	if c.Dashboard.LowCredit < 0 {
+		return i18n.Errorf("dashboard.low_credit must not be negative")
+	}
+	if c.Dashboard.Recent < 1 {
+		return i18n.Errorf("dashboard.recent must be at least 1")
+	}
+	if c.LineNumber != "" {
+		if _, err := c.ResolveLine(c.LineNumber); err != nil {
+			return i18n.Errorf("line_number: %w", err)
+		}
+	}
+	return c.validateLines()
 }
 
-// createDefaultConfig creates a default configuration file
-func createDefaultConfig(configFile string) error {
-	cfg := DefaultConfig()
-	data, err := json.MarshalIndent(cfg, "", "  ")
+// writeJSON writes v to the configuration file
+func writeJSON(configFile string, v interface{}) error {
+	if ReadOnly() {
+		return ErrReadOnly
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
-		return err
+		return i18n.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configFile), defaultConfigPerms); err != nil {
+		return i18n.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(configFile, data, defaultFilePerms); err != nil {
+		return i18n.Errorf("failed to write config file: %w", err)
+	}
+
+	// WriteFile keeps the mode of an existing file, which may be too loose
+	if err := os.Chmod(configFile, defaultFilePerms); err != nil {
+		return i18n.Errorf("failed to set config file permissions: %w", err)
+	}
+
+	return nil
+}
+
+// FilePath returns the path of the configuration file
+func FilePath() (string, error) {
+	return getConfigPath()
+}
+
+// IsPrivate reports whether a file mode grants no access to group or others
+func IsPrivate(mode os.FileMode) bool {
+	return mode.Perm()&0077 == 0
+}
+
+// PrivateMode returns the permissions files and directories in the config directory should have
+func PrivateMode(isDir bool) os.FileMode {
+	if isDir {
+		return defaultConfigPerms
 	}
-	return os.WriteFile(configFile, data, defaultFilePerms)
+	return defaultFilePerms
 }
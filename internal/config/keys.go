@@ -0,0 +1,167 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+)
+
+// Key describes a configuration key that can be read and changed with
+// config get/set/unset. Keys are derived from the Config struct tags, so a new
+// field is available without new commands.
+type Key struct {
+	Name        string
+	Description string
+	Secret      bool
+
+	index []int
+}
+
+// Keys returns every configuration key in declaration order
+func Keys() []Key {
+	return collectKeys(reflect.TypeOf(Config{}), "", nil)
+}
+
+// collectKeys walks a struct type and returns its keys, descending into nested structs
+func collectKeys(t reflect.Type, prefix string, index []int) []Key {
+	var keys []Key
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || name == "" || name == "-" || field.Tag.Get("config") == "-" {
+			continue
+		}
+
+		fieldIndex := append(append([]int(nil), index...), i)
+		if field.Type.Kind() == reflect.Struct {
+			keys = append(keys, collectKeys(field.Type, prefix+name+".", fieldIndex)...)
+			continue
+		}
+
+		keys = append(keys, Key{
+			Name:        prefix + name,
+			Description: field.Tag.Get("desc"),
+			Secret:      field.Tag.Get("secret") == "true",
+			index:       fieldIndex,
+		})
+	}
+	return keys
+}
+
+// LookupKey returns the key with the given name
+func LookupKey(name string) (Key, error) {
+	for _, k := range Keys() {
+		if k.Name == name {
+			return k, nil
+		}
+	}
+	return Key{}, i18n.Errorf("unknown config key %q (see 'smsir config list')", name)
+}
+
+// stringList is the type of keys written as comma separated text
+var stringList = reflect.TypeOf([]string(nil))
+
+// Get returns the value of a key formatted as text. Lists of strings are comma
+// separated; other lists and maps are JSON.
+func (c *Config) Get(name string) (string, error) {
+	k, err := LookupKey(name)
+	if err != nil {
+		return "", err
+	}
+
+	v := reflect.ValueOf(c).Elem().FieldByIndex(k.index)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Slice, reflect.Map:
+		if v.Type() == stringList {
+			return strings.Join(v.Interface().([]string), ","), nil
+		}
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return "", i18n.Errorf("failed to encode %s: %w", name, err)
+		}
+		return string(data), nil
+	}
+	return "", i18n.Errorf("config key %q has an unsupported type", name)
+}
+
+// Set parses value and assigns it to a key, written as Get returns it
+func (c *Config) Set(name, value string) error {
+	k, err := LookupKey(name)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(c).Elem().FieldByIndex(k.index)
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return i18n.Errorf("%s must be a whole number", name)
+		}
+		v.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return i18n.Errorf("%s must be true or false", name)
+		}
+		v.SetBool(b)
+	case reflect.Slice, reflect.Map:
+		if v.Type() == stringList {
+			var items []string
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			v.Set(reflect.ValueOf(items))
+			break
+		}
+		parsed := reflect.New(v.Type())
+		if err := json.Unmarshal([]byte(value), parsed.Interface()); err != nil {
+			return i18n.Errorf("%s must be JSON: %w", name, err)
+		}
+		v.Set(parsed.Elem())
+	default:
+		return i18n.Errorf("config key %q has an unsupported type", name)
+	}
+	c.markChanged(name)
+	return nil
+}
+
+// Unset restores the default value of a key. Unsetting the API key also
+// removes it from the secret backend.
+func (c *Config) Unset(name string) error {
+	k, err := LookupKey(name)
+	if err != nil {
+		return err
+	}
+
+	if name == "api_key" && c.APIKeyRef != "" {
+		deleteSecret(c.APIKeyRef)
+		c.APIKeyRef = ""
+	}
+
+	def := reflect.ValueOf(DefaultConfig()).Elem().FieldByIndex(k.index)
+	reflect.ValueOf(c).Elem().FieldByIndex(k.index).Set(def)
+	c.markChanged(name)
+	return nil
+}
+
+// markChanged records that a key was changed, so SaveConfig keeps its value
+func (c *Config) markChanged(name string) {
+	if c.changed == nil {
+		c.changed = make(map[string]bool)
+	}
+	c.changed[name] = true
+}
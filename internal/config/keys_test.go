@@ -0,0 +1,88 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeys(t *testing.T) {
+	names := make(map[string]bool)
+	for _, k := range Keys() {
+		names[k.Name] = true
+	}
+
+	for _, name := range []string{"api_key", "line_number", "optout.keywords", "failover.profiles", "dashboard.refresh", "lines"} {
+		if !names[name] {
+			t.Errorf("Keys() is missing %q", name)
+		}
+	}
+	// Hidden and unstored fields are not keys
+	for _, name := range []string{"api_key_ref", "profile", "optout", "dashboard"} {
+		if names[name] {
+			t.Errorf("Keys() should not contain %q", name)
+		}
+	}
+}
+
+func TestSetGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "string", key: "line_number", value: "3000123", want: "3000123"},
+		{name: "int", key: "dashboard.refresh", value: "30", want: "30"},
+		{name: "int that is not a number", key: "dashboard.refresh", value: "soon", wantErr: true},
+		{name: "string list", key: "optout.keywords", value: " STOP, لغو ,,", want: "STOP,لغو"},
+		{name: "empty string list", key: "failover.profiles", value: "", want: ""},
+		{name: "map", key: "lines", value: `{"otp":{"number":"3000123","weight":2}}`, want: `{"otp":{"number":"3000123","weight":2}}`},
+		{name: "map that is not json", key: "lines", value: "otp=3000123", wantErr: true},
+		{name: "unknown key", key: "colour", value: "red", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			err := cfg.Set(tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !cfg.changed[tt.key] {
+				t.Errorf("Set(%q) did not mark the key as changed", tt.key)
+			}
+
+			got, err := cfg.Get(tt.key)
+			if err != nil {
+				t.Fatalf("Get(%q) error = %v", tt.key, err)
+			}
+			if got != tt.want {
+				t.Errorf("Get(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnset(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.OptOut.Keywords = []string{"STOP"}
+	cfg.Dashboard.Refresh = 5
+
+	for _, key := range []string{"optout.keywords", "dashboard.refresh"} {
+		if err := cfg.Unset(key); err != nil {
+			t.Fatalf("Unset(%q) error = %v", key, err)
+		}
+		if !cfg.changed[key] {
+			t.Errorf("Unset(%q) did not mark the key as changed", key)
+		}
+	}
+
+	def := DefaultConfig()
+	if !reflect.DeepEqual(cfg.OptOut, def.OptOut) || cfg.Dashboard != def.Dashboard {
+		t.Errorf("Unset() left %+v %+v, want the defaults %+v %+v", cfg.OptOut, cfg.Dashboard, def.OptOut, def.Dashboard)
+	}
+}
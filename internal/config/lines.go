@@ -0,0 +1,174 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/phone"
+)
+
+// Line is a named sender line with its own defaults, e.g. "otp" or "marketing"
+type Line struct {
+	// Name is the alias; it is the key of Config.Lines and not stored in the entry
+	Name      string  `json:"-" yaml:"-"`
+	Number    string  `json:"number" yaml:"number"`
+	Signature string  `json:"signature,omitempty" yaml:"signature"`
+	Tariff    float64 `json:"tariff,omitempty" yaml:"tariff"`
+	SendHours string  `json:"send_hours,omitempty" yaml:"send_hours"`
+	// Operator and Weight are used when a send is spread across several lines
+	Operator string `json:"operator,omitempty" yaml:"operator"`
+	Weight   int    `json:"weight,omitempty" yaml:"weight"`
+}
+
+// ResolveLine returns the line for an alias or a plain line number
+func (c *Config) ResolveLine(nameOrNumber string) (Line, error) {
+	nameOrNumber = strings.TrimSpace(nameOrNumber)
+	if nameOrNumber == "" {
+		return Line{}, i18n.Errorf("line number is required")
+	}
+
+	if line, ok := c.Lines[nameOrNumber]; ok {
+		line.Name = nameOrNumber
+		return line, nil
+	}
+
+	if _, err := strconv.ParseInt(nameOrNumber, 10, 64); err != nil {
+		return Line{}, i18n.Errorf("unknown line %q (not a number or a configured alias)", nameOrNumber)
+	}
+
+	// A plain number still picks up the settings of an alias that uses it
+	for _, name := range c.LineNames() {
+		if c.Lines[name].Number == nameOrNumber {
+			line := c.Lines[name]
+			line.Name = name
+			return line, nil
+		}
+	}
+	return Line{Number: nameOrNumber}, nil
+}
+
+// LineNames returns the configured line aliases in alphabetical order
+func (c *Config) LineNames() []string {
+	names := make([]string, 0, len(c.Lines))
+	for name := range c.Lines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LineAliases returns the aliases that refer to a line number
+func (c *Config) LineAliases(number string) []string {
+	var aliases []string
+	for _, name := range c.LineNames() {
+		if c.Lines[name].Number == number {
+			aliases = append(aliases, name)
+		}
+	}
+	return aliases
+}
+
+// Int returns the line number as sent to the API
+func (l Line) Int() (int64, error) {
+	n, err := strconv.ParseInt(l.Number, 10, 64)
+	if err != nil {
+		return 0, i18n.Errorf("invalid line number %q", l.Number)
+	}
+	return n, nil
+}
+
+// Sign appends the line's signature to a message unless it already ends with it
+func (l Line) Sign(text string) string {
+	if l.Signature == "" || strings.HasSuffix(strings.TrimSpace(text), l.Signature) {
+		return text
+	}
+	return text + "\n" + l.Signature
+}
+
+// Label returns the line number with its alias, e.g. "30001234 (marketing)"
+func (l Line) Label() string {
+	if l.Name == "" {
+		return l.Number
+	}
+	return fmt.Sprintf("%s (%s)", l.Number, l.Name)
+}
+
+// CheckHours returns an error when t is outside the line's allowed send hours
+func (l Line) CheckHours(t time.Time) error {
+	if l.SendHours == "" {
+		return nil
+	}
+
+	from, to, err := parseHours(l.SendHours)
+	if err != nil {
+		return err
+	}
+
+	now := t.Hour()*60 + t.Minute()
+	allowed := now >= from && now < to
+	if from > to {
+		// The window wraps around midnight, e.g. 22:00-06:00
+		allowed = now >= from || now < to
+	}
+	if !allowed {
+		return i18n.Errorf("line %s may only send between %s", l.Label(), strings.Replace(l.SendHours, "-", " and ", 1))
+	}
+	return nil
+}
+
+// parseHours parses a window such as "08:00-21:00" into minutes since midnight
+func parseHours(hours string) (from, to int, err error) {
+	start, end, ok := strings.Cut(hours, "-")
+	if !ok {
+		return 0, 0, i18n.Errorf("send hours %q must look like 08:00-21:00", hours)
+	}
+	if from, err = parseClock(start); err != nil {
+		return 0, 0, i18n.Errorf("send hours %q must look like 08:00-21:00", hours)
+	}
+	if to, err = parseClock(end); err != nil {
+		return 0, 0, i18n.Errorf("send hours %q must look like 08:00-21:00", hours)
+	}
+	return from, to, nil
+}
+
+// parseClock parses "HH:MM" into minutes since midnight
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// validateLines checks the configured line aliases
+func (c *Config) validateLines() error {
+	for _, name := range c.LineNames() {
+		line := c.Lines[name]
+		if _, err := strconv.ParseInt(name, 10, 64); err == nil {
+			return i18n.Errorf("line alias %q must not be a number", name)
+		}
+		if _, err := strconv.ParseInt(line.Number, 10, 64); err != nil {
+			return i18n.Errorf("line %q: number %q is not a line number", name, line.Number)
+		}
+		if line.Tariff < 0 {
+			return i18n.Errorf("line %q: tariff must not be negative", name)
+		}
+		if line.SendHours != "" {
+			if _, _, err := parseHours(line.SendHours); err != nil {
+				return i18n.Errorf("line %q: %w", name, err)
+			}
+		}
+		if line.Weight < 0 {
+			return i18n.Errorf("line %q: weight must not be negative", name)
+		}
+		if line.Operator != "" && !slices.Contains(phone.Operators(), line.Operator) {
+			return i18n.Errorf("line %q: unknown operator %q (use one of %s)", name, line.Operator, strings.Join(phone.Operators(), ", "))
+		}
+	}
+	return nil
+}
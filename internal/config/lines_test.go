@@ -0,0 +1,117 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveLine(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Lines = map[string]Line{
+		"otp":       {Number: "3000123", Signature: "Acme"},
+		"marketing": {Number: "3000999", SendHours: "08:00-21:00"},
+	}
+
+	tests := []struct {
+		name    string
+		line    string
+		want    Line
+		wantErr bool
+	}{
+		{name: "alias", line: "otp", want: Line{Name: "otp", Number: "3000123", Signature: "Acme"}},
+		{name: "alias with spaces", line: " marketing ", want: Line{Name: "marketing", Number: "3000999", SendHours: "08:00-21:00"}},
+		{name: "number of an alias", line: "3000123", want: Line{Name: "otp", Number: "3000123", Signature: "Acme"}},
+		{name: "other number", line: "3000555", want: Line{Number: "3000555"}},
+		{name: "unknown alias", line: "support", wantErr: true},
+		{name: "empty", line: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cfg.ResolveLine(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveLine(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveLine(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHours(t *testing.T) {
+	tests := []struct {
+		hours    string
+		from, to int
+		wantErr  bool
+	}{
+		{hours: "08:00-21:00", from: 8 * 60, to: 21 * 60},
+		{hours: "22:30 - 06:15", from: 22*60 + 30, to: 6*60 + 15},
+		{hours: "8-21", wantErr: true},
+		{hours: "08:00", wantErr: true},
+		{hours: "08:00-25:00", wantErr: true},
+		{hours: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		from, to, err := parseHours(tt.hours)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseHours(%q) error = %v, wantErr %v", tt.hours, err, tt.wantErr)
+			continue
+		}
+		if from != tt.from || to != tt.to {
+			t.Errorf("parseHours(%q) = %d, %d, want %d, %d", tt.hours, from, to, tt.from, tt.to)
+		}
+	}
+}
+
+func TestCheckHours(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		hours   string
+		t       time.Time
+		wantErr bool
+	}{
+		{name: "no window", hours: "", t: at(3, 0)},
+		{name: "inside", hours: "08:00-21:00", t: at(12, 0)},
+		{name: "at the start", hours: "08:00-21:00", t: at(8, 0)},
+		{name: "at the end", hours: "08:00-21:00", t: at(21, 0), wantErr: true},
+		{name: "before", hours: "08:00-21:00", t: at(7, 59), wantErr: true},
+		{name: "overnight before midnight", hours: "22:00-06:00", t: at(23, 0)},
+		{name: "overnight after midnight", hours: "22:00-06:00", t: at(5, 59)},
+		{name: "overnight during the day", hours: "22:00-06:00", t: at(12, 0), wantErr: true},
+		{name: "invalid window", hours: "soon", t: at(12, 0), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := Line{Number: "3000123", SendHours: tt.hours}
+			if err := line.CheckHours(tt.t); (err != nil) != tt.wantErr {
+				t.Errorf("CheckHours(%s) with %q error = %v, wantErr %v", tt.t.Format("15:04"), tt.hours, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	line := Line{Number: "3000123", Signature: "Acme"}
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Your code is 1234", "Your code is 1234\nAcme"},
+		{"Your code is 1234\nAcme", "Your code is 1234\nAcme"},
+		{"Your code is 1234\nAcme\n", "Your code is 1234\nAcme\n"},
+	}
+
+	for _, tt := range tests {
+		if got := line.Sign(tt.text); got != tt.want {
+			t.Errorf("Sign(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+)
+
+// DefaultProfileName is the profile used when none has been chosen
+const DefaultProfileName = "default"
+
+// selectedProfile is the profile chosen with SetProfile, e.g. from --profile
+var selectedProfile string
+
+// SetProfile selects the profile LoadConfig and SaveConfig use for this process
+func SetProfile(name string) {
+	selectedProfile = name
+}
+
+// File is the on-disk configuration holding every named profile
+type File struct {
+	DefaultProfile string             `json:"default_profile"`
+	SecretBackend  string             `json:"secret_backend,omitempty"`
+	Profiles       map[string]*Config `json:"profiles"`
+
+	path    string
+	project *Project
+	// projectErr is a setting the project file may not make; the file is then ignored
+	projectErr error
+	// exists is false for the empty file used in read-only mode when none exists
+	exists bool
+	// staleRefs are secrets of renamed profiles, deleted by the next Save
+	staleRefs []string
+}
+
+// LoadFile reads the configuration file, creating it if it does not exist.
+// In read-only mode a missing file is not created and an empty one is used.
+// Files written before profiles existed hold a single configuration at the top
+// level; they are migrated into a "default" profile and rewritten on the next save.
+func LoadFile() (*File, error) {
+	configFile, err := getConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	project, projectErr := LoadProject()
+	var settingErr *ProjectSettingError
+	if projectErr != nil && !errors.As(projectErr, &settingErr) {
+		return nil, projectErr
+	}
+
+	// Check if config file exists
+	if _, err := os.Stat(configFile); os.IsNotExist(err) {
+		emptyFile := &File{
+			Profiles:   make(map[string]*Config),
+			path:       configFile,
+			project:    project,
+			projectErr: projectErr,
+		}
+		if ReadOnly() {
+			return emptyFile, nil
+		}
+
+		// Config file doesn't exist, create default one
+		if err := createDefaultConfig(configFile); err != nil {
+			if switchToReadOnly(err) {
+				return emptyFile, nil
+			}
+			return nil, i18n.Errorf("failed to create default config: %w", err)
+		}
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, i18n.Errorf("failed to read config file: %w", err)
+	}
+
+	// Decoded with encoding/json rather than viper, which lower-cases map keys
+	// and would rename profiles such as "Marketing"
+	var raw struct {
+		DefaultProfile string                     `json:"default_profile"`
+		SecretBackend  string                     `json:"secret_backend"`
+		Profiles       map[string]json.RawMessage `json:"profiles"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, i18n.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	f := &File{
+		exists:         true,
+		project:        project,
+		projectErr:     projectErr,
+		DefaultProfile: raw.DefaultProfile,
+		SecretBackend:  raw.SecretBackend,
+		Profiles:       make(map[string]*Config),
+		path:           configFile,
+	}
+
+	if raw.Profiles == nil {
+		// Legacy single-profile file
+		raw.Profiles = map[string]json.RawMessage{DefaultProfileName: data}
+		f.DefaultProfile = DefaultProfileName
+	}
+
+	for name, profileData := range raw.Profiles {
+		cfg := DefaultConfig()
+		if err := json.Unmarshal(profileData, cfg); err != nil {
+			return nil, i18n.Errorf("failed to unmarshal profile %q: %w", name, err)
+		}
+		cfg.Profile = name
+		f.Profiles[name] = cfg
+	}
+
+	return f, nil
+}
+
+// Save writes the configuration file. API keys held in memory are stored in
+// the secret backend and only their references are written to the file.
+func (f *File) Save() error {
+	if ReadOnly() {
+		return ErrReadOnly
+	}
+
+	stored := &File{
+		DefaultProfile: f.DefaultProfile,
+		SecretBackend:  f.SecretBackend,
+		Profiles:       make(map[string]*Config, len(f.Profiles)),
+	}
+
+	for name, cfg := range f.Profiles {
+		c := *cfg
+		if c.APIKey != "" && !c.apiKeyFromEnv {
+			ref, err := f.storeSecret(name, c.APIKey)
+			if err != nil {
+				return err
+			}
+			c.APIKeyRef = ref
+			cfg.APIKeyRef = ref
+		}
+		c.APIKey = ""
+		stored.Profiles[name] = &c
+	}
+
+	if err := writeJSON(f.path, stored); err != nil {
+		return err
+	}
+
+	// The old secrets are removed only once the file no longer points to them
+	used := make(map[string]bool, len(stored.Profiles))
+	for _, c := range stored.Profiles {
+		used[c.APIKeyRef] = true
+	}
+	for _, ref := range f.staleRefs {
+		if !used[ref] {
+			deleteSecret(ref)
+		}
+	}
+	f.staleRefs = nil
+	return nil
+}
+
+// ActiveProfile returns the profile selected by SetProfile, SMSIR_PROFILE
+// or the file default
+func (f *File) ActiveProfile() string {
+	name, _ := f.activeProfile()
+	return name
+}
+
+// activeProfile returns the active profile and where it was chosen
+func (f *File) activeProfile() (name, source string) {
+	if selectedProfile != "" {
+		return selectedProfile, SourceFlag
+	}
+	if env := os.Getenv("SMSIR_PROFILE"); env != "" {
+		return env, SourceEnv + " SMSIR_PROFILE"
+	}
+	if f.DefaultProfile != "" {
+		return f.DefaultProfile, SourceFile
+	}
+	return DefaultProfileName, SourceDefault
+}
+
+// Names returns the profile names in alphabetical order
+func (f *File) Names() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Add creates a new profile
+func (f *File) Add(name string, cfg *Config) error {
+	if name == "" {
+		return i18n.Errorf("profile name is required")
+	}
+	if _, exists := f.Profiles[name]; exists {
+		return i18n.Errorf("profile %q already exists", name)
+	}
+
+	cfg.Profile = name
+	f.Profiles[name] = cfg
+	if f.DefaultProfile == "" {
+		f.DefaultProfile = name
+	}
+	return nil
+}
+
+// Use makes a profile the default
+func (f *File) Use(name string) error {
+	if _, exists := f.Profiles[name]; !exists {
+		return i18n.Errorf("profile %q not found", name)
+	}
+	f.DefaultProfile = name
+	return nil
+}
+
+// Remove deletes a profile; the default profile cannot be removed while others exist
+func (f *File) Remove(name string) error {
+	if _, exists := f.Profiles[name]; !exists {
+		return i18n.Errorf("profile %q not found", name)
+	}
+	if name == f.DefaultProfile && len(f.Profiles) > 1 {
+		return i18n.Errorf("profile %q is the default; choose another default first", name)
+	}
+
+	if ref := f.Profiles[name].APIKeyRef; ref != "" {
+		deleteSecret(ref)
+	}
+
+	delete(f.Profiles, name)
+	if name == f.DefaultProfile {
+		f.DefaultProfile = ""
+	}
+	return nil
+}
+
+// Rename changes the name of a profile, keeping it the default if it was.
+// Stored API keys are named after their profile, so the key is moved to the
+// new name by the next Save; otherwise a new profile with the old name would
+// overwrite or delete it.
+func (f *File) Rename(oldName, newName string) error {
+	cfg, exists := f.Profiles[oldName]
+	if !exists {
+		return i18n.Errorf("profile %q not found", oldName)
+	}
+	if newName == "" {
+		return i18n.Errorf("profile name is required")
+	}
+	if _, exists := f.Profiles[newName]; exists {
+		return i18n.Errorf("profile %q already exists", newName)
+	}
+
+	if cfg.APIKeyRef != "" {
+		if cfg.APIKey == "" {
+			key, err := resolveSecret(cfg.APIKeyRef)
+			if err != nil {
+				return i18n.Errorf("failed to read API key of profile %q (%s): %w", oldName, cfg.APIKeyRef, err)
+			}
+			cfg.APIKey = key
+		}
+		f.staleRefs = append(f.staleRefs, cfg.APIKeyRef)
+		cfg.APIKeyRef = ""
+	}
+
+	delete(f.Profiles, oldName)
+	cfg.Profile = newName
+	f.Profiles[newName] = cfg
+	if f.DefaultProfile == oldName {
+		f.DefaultProfile = newName
+	}
+	return nil
+}
+
+// createDefaultConfig creates a default configuration file
+func createDefaultConfig(configFile string) error {
+	return writeJSON(configFile, &File{
+		DefaultProfile: DefaultProfileName,
+		Profiles:       map[string]*Config{DefaultProfileName: DefaultConfig()},
+	})
+}
+
+// LoadProfileForEdit loads the active profile as stored in the config file,
+// without project or environment values and without reading its API key, so
+// that saving it writes back only what was changed. A profile that does not
+// exist yet starts from the defaults.
+func LoadProfileForEdit() (*Config, error) {
+	f, err := LoadFile()
+	if err != nil {
+		return nil, err
+	}
+
+	name := f.ActiveProfile()
+	cfg := f.Profiles[name]
+	if cfg == nil {
+		cfg = DefaultConfig()
+		cfg.Profile = name
+	}
+	return cfg, nil
+}
+
+// LoadProfile loads a profile other than the active one, e.g. to fail over to.
+// Only the user file is read: the project file and SMSIR_* variables configure
+// the active profile and are not applied.
+func LoadProfile(name string) (*Config, error) {
+	f, err := LoadFile()
+	if err != nil {
+		return nil, err
+	}
+
+	stored, ok := f.Profiles[name]
+	if !ok {
+		return nil, i18n.Errorf("profile %q not found", name)
+	}
+	cfg := *stored
+	cfg.Profile = name
+
+	if cfg.APIKey == "" && cfg.APIKeyRef != "" {
+		key, err := resolveSecret(cfg.APIKeyRef)
+		if err != nil {
+			return nil, i18n.Errorf("failed to read API key of profile %q (%s): %w", name, cfg.APIKeyRef, err)
+		}
+		cfg.APIKey = key
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+// projectFileNames are the project-local configuration files, in order of preference
+var projectFileNames = []string{".smsir.yaml", ".smsir.yml", ".smsir.json"}
+
+// Project is a project-local configuration file, such as a .smsir.yaml
+// committed to a repository. It can pin the line and other settings but never
+// holds API keys, which stay in the user configuration. Since any directory
+// smsir runs in may contain one, it cannot choose the profile whose key is
+// used, the API address the key is sent to or the signatures appended to
+// messages either.
+type Project struct {
+	// Path is the file the project configuration was read from
+	Path string `json:"-" yaml:"-"`
+
+	LineNumber string        `json:"line_number" yaml:"line_number"`
+	OptOut     *OptOutConfig `json:"optout" yaml:"optout"`
+	// Lines add to or replace the user's line aliases
+	Lines map[string]Line `json:"lines" yaml:"lines"`
+
+	// Profile and BaseURL are only decoded to reject them with a clear error
+	Profile string `json:"profile" yaml:"profile"`
+	BaseURL string `json:"base_url" yaml:"base_url"`
+}
+
+// ProjectSettingError reports a setting that only the user configuration may
+// make, such as base_url, found in a project file. Commands that only edit
+// the user configuration warn about it and go on without the project file.
+type ProjectSettingError struct {
+	err error
+}
+
+// Error returns the message of the rejected setting
+func (e *ProjectSettingError) Error() string {
+	return e.err.Error()
+}
+
+// project caches the result of LoadProject for the process
+var project struct {
+	loaded bool
+	value  *Project
+	err    error
+}
+
+// LoadProject finds the nearest project file by walking up from the working
+// directory. It returns nil when there is none.
+func LoadProject() (*Project, error) {
+	if !project.loaded {
+		project.value, project.err = findProject()
+		project.loaded = true
+	}
+	return project.value, project.err
+}
+
+// findProject walks up from the working directory to the first project file
+func findProject() (*Project, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, nil
+	}
+
+	for {
+		for _, name := range projectFileNames {
+			path := filepath.Join(dir, name)
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() {
+				continue
+			}
+			return readProject(path)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// readProject parses a project file. Unknown keys are rejected so a typo or
+// an api_key that should not be committed is reported instead of ignored.
+func readProject(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("failed to read project config %s: %w", path, err)
+	}
+
+	p := &Project{Path: path}
+	if strings.HasSuffix(path, ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(p)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(p)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, i18n.Errorf("failed to parse project config %s: %w", path, err)
+	}
+
+	if p.Profile != "" {
+		return nil, &ProjectSettingError{i18n.Errorf("project config %s cannot choose the profile; use --profile or SMSIR_PROFILE", path)}
+	}
+	if p.BaseURL != "" {
+		return nil, &ProjectSettingError{i18n.Errorf("project config %s cannot set base_url; set it in the user configuration", path)}
+	}
+	for name, line := range p.Lines {
+		if line.Signature != "" {
+			return nil, &ProjectSettingError{i18n.Errorf("project config %s cannot set the signature of line %q; set it with 'smsir lines alias set'", path, name)}
+		}
+	}
+
+	return p, nil
+}
+
+// apply overrides configuration values with the ones set in the project file
+func (p *Project) apply(cfg *Config) {
+	if p == nil {
+		return
+	}
+	if p.LineNumber != "" {
+		cfg.LineNumber = p.LineNumber
+		cfg.setSource("line_number", SourceProject)
+	}
+	if len(p.Lines) > 0 {
+		// A new map keeps the project's lines out of the stored profile
+		lines := make(map[string]Line, len(cfg.Lines)+len(p.Lines))
+		for name, line := range cfg.Lines {
+			lines[name] = line
+		}
+		for name, line := range p.Lines {
+			// Signatures only come from the user configuration
+			line.Signature = lines[name].Signature
+			lines[name] = line
+		}
+		cfg.Lines = lines
+		cfg.setSource("lines", SourceProject)
+	}
+	if p.OptOut != nil {
+		if len(p.OptOut.Keywords) > 0 {
+			cfg.OptOut.Keywords = p.OptOut.Keywords
+		}
+		if p.OptOut.ConfirmMessage != "" {
+			cfg.OptOut.ConfirmMessage = p.OptOut.ConfirmMessage
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// ErrReadOnly is returned by operations that would write to disk in read-only mode
+var ErrReadOnly = errors.New("configuration is read-only (SMSIR_READ_ONLY is set or the config directory is not writable)")
+
+// readOnly caches the result of ReadOnly for the process
+var readOnly struct {
+	checked bool
+	value   bool
+}
+
+// ReadOnly reports whether smsir must not write to disk. It is forced with
+// SMSIR_READ_ONLY=1 (or disabled with SMSIR_READ_ONLY=0) and otherwise chosen
+// automatically when the configuration directory cannot be written, as in
+// read-only containers and CI runners. Configuration then comes from the
+// SMSIR_* environment variables and any existing files are only read.
+func ReadOnly() bool {
+	if !readOnly.checked {
+		readOnly.value = detectReadOnly()
+		readOnly.checked = true
+	}
+	return readOnly.value
+}
+
+// detectReadOnly applies SMSIR_READ_ONLY or checks the directories smsir
+// writes to: the one of the config file in use, which --config and
+// SMSIR_CONFIG can move elsewhere, and the configuration directory holding
+// local state
+func detectReadOnly() bool {
+	switch strings.ToLower(os.Getenv("SMSIR_READ_ONLY")) {
+	case "1", "true", "yes":
+		return true
+	case "0", "false", "no":
+		return false
+	}
+
+	configFile, err := getConfigPath()
+	if err != nil {
+		// Without a home directory there is nowhere to write
+		return true
+	}
+	dir, err := dirPath()
+	if err != nil {
+		return true
+	}
+	return !canCreateIn(filepath.Dir(configFile)) || !canCreateIn(dir)
+}
+
+// canCreateIn reports whether dir can be written. It may not exist yet; its
+// nearest existing parent then decides.
+func canCreateIn(dir string) bool {
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return writable(dir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
+
+// switchToReadOnly turns on read-only mode after a write failed because the
+// filesystem refused it, which the up-front check cannot always predict
+// (e.g. for root on a read-only mount). It reports whether it did.
+func switchToReadOnly(err error) bool {
+	if os.Getenv("SMSIR_READ_ONLY") != "" {
+		return false
+	}
+	if !errors.Is(err, fs.ErrPermission) && !errors.Is(err, syscall.EROFS) {
+		return false
+	}
+	readOnly.checked = true
+	readOnly.value = true
+	return true
+}
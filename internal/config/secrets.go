@@ -0,0 +1,242 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/secret"
+	"golang.org/x/term"
+)
+
+const (
+	// secretsFileName is the name of the encrypted secrets file used by the file backend
+	secretsFileName = "secrets.enc"
+	// apiKeyEnv is the environment variable holding the API key in env-only mode
+	apiKeyEnv = "SMSIR_API_KEY"
+)
+
+// fileBackend is shared so the passphrase is asked for at most once per process
+var fileBackend *secret.FileBackend
+
+var (
+	// passphrase is the secrets file passphrase once it was read
+	passphrase string
+	// noPrompt is set once a full-screen UI owns the terminal, which must not be read from
+	noPrompt bool
+)
+
+// UnlockSecrets reads the secrets file passphrase while the terminal is still
+// free, before a full-screen UI takes it over. It does nothing unless the
+// encrypted file backend is used and SMSIR_PASSPHRASE is unset. Afterwards the
+// passphrase is never asked for on the terminal; a missing one is reported
+// with a hint to set SMSIR_PASSPHRASE instead.
+func UnlockSecrets() error {
+	defer func() { noPrompt = true }()
+
+	if ReadOnly() || passphrase != "" || os.Getenv("SMSIR_PASSPHRASE") != "" {
+		return nil
+	}
+	f, err := LoadFile()
+	if err != nil {
+		return err
+	}
+	if f.SecretBackendName() != secret.BackendFile {
+		return nil
+	}
+	_, err = readPassphrase()
+	return err
+}
+
+// SecretBackendName returns the configured secret backend, resolving the
+// automatic choice to the OS keyring when available and the encrypted file otherwise
+func (f *File) SecretBackendName() string {
+	if f.SecretBackend != "" {
+		return f.SecretBackend
+	}
+	if (secret.KeyringBackend{}).Available() {
+		return secret.BackendKeyring
+	}
+	return secret.BackendFile
+}
+
+// SetSecretBackend moves every stored API key to another backend
+func (f *File) SetSecretBackend(name string) error {
+	newBackend, err := backendByName(name)
+	if err != nil {
+		return err
+	}
+
+	// Read every key with its current backend before switching; the env
+	// backend stores none, so its keys are dropped instead
+	for profileName, cfg := range f.Profiles {
+		if newBackend.Name() == secret.BackendEnv {
+			cfg.APIKey = ""
+			continue
+		}
+		if cfg.APIKey == "" && cfg.APIKeyRef != "" {
+			key, err := resolveSecret(cfg.APIKeyRef)
+			if err != nil {
+				return i18n.Errorf("failed to read API key of profile %q: %w", profileName, err)
+			}
+			cfg.APIKey = key
+		}
+	}
+
+	oldRefs := make(map[string]string)
+	for profileName, cfg := range f.Profiles {
+		if cfg.APIKeyRef != "" {
+			oldRefs[profileName] = cfg.APIKeyRef
+		}
+		cfg.APIKeyRef = ""
+	}
+
+	f.SecretBackend = newBackend.Name()
+	if err := f.Save(); err != nil {
+		return err
+	}
+
+	// Clean up the old copies once the new ones are safely stored
+	for _, ref := range oldRefs {
+		deleteSecret(ref)
+	}
+	return nil
+}
+
+// HasPlaintextKeys reports whether the file still holds API keys in plaintext
+func (f *File) HasPlaintextKeys() bool {
+	for _, cfg := range f.Profiles {
+		if cfg.APIKey != "" && cfg.APIKeyRef == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// storeSecret saves an API key in the file's backend and returns its reference
+func (f *File) storeSecret(profileName, value string) (string, error) {
+	backend, err := backendByName(f.SecretBackendName())
+	if err != nil {
+		return "", err
+	}
+
+	account := profileName
+	if backend.Name() == secret.BackendEnv {
+		account = apiKeyEnv
+	} else if dir, err := dirPath(); err == nil && f.path != filepath.Join(dir, configFileName) {
+		// Keys of a file chosen with --config or SMSIR_CONFIG must not replace
+		// the keys of same-named profiles in the user configuration
+		account = profileName + "@" + f.path
+	}
+
+	if err := backend.Set(account, value); err != nil {
+		return "", i18n.Errorf("failed to store API key in %s: %w", backend.Name(), err)
+	}
+	return secret.FormatRef(backend.Name(), account), nil
+}
+
+// resolveSecret reads the secret a reference points to
+func resolveSecret(ref string) (string, error) {
+	name, account, err := secret.ParseRef(ref)
+	if err != nil {
+		return "", err
+	}
+	backend, err := backendByName(name)
+	if err != nil {
+		return "", err
+	}
+	return backend.Get(account)
+}
+
+// deleteSecret removes the secret a reference points to; errors are ignored
+// because a leftover secret is harmless compared to failing the operation
+func deleteSecret(ref string) {
+	name, account, err := secret.ParseRef(ref)
+	if err != nil {
+		return
+	}
+	if backend, err := backendByName(name); err == nil {
+		_ = backend.Delete(account)
+	}
+}
+
+// backendByName returns the secret backend with the given name
+func backendByName(name string) (secret.Backend, error) {
+	switch name {
+	case secret.BackendKeyring:
+		return secret.KeyringBackend{}, nil
+	case secret.BackendEnv:
+		return secret.EnvBackend{}, nil
+	case secret.BackendFile:
+		if fileBackend == nil {
+			dir, err := Dir()
+			if err != nil {
+				return nil, err
+			}
+			fileBackend = &secret.FileBackend{
+				Path:       filepath.Join(dir, secretsFileName),
+				Passphrase: readPassphrase,
+			}
+		}
+		return fileBackend, nil
+	}
+	return nil, i18n.Errorf("unknown secret backend %q (use keyring, file or env)", name)
+}
+
+// readPassphrase returns SMSIR_PASSPHRASE or asks for the passphrase on the
+// terminal. A typed passphrase is tried on the existing secrets file, or asked
+// for twice when the file is created, before it is kept for the process.
+func readPassphrase() (string, error) {
+	if env := os.Getenv("SMSIR_PASSPHRASE"); env != "" {
+		return env, nil
+	}
+	if passphrase != "" {
+		return passphrase, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if noPrompt || !term.IsTerminal(fd) {
+		return "", i18n.Errorf("set SMSIR_PASSPHRASE to unlock the secrets file")
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, secretsFileName)
+
+	typed, err := promptPassphrase(fd, i18n.T("🔑 Passphrase for the SMS.ir secrets file: "))
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		check := &secret.FileBackend{Path: path, Passphrase: func() (string, error) { return typed, nil }}
+		if err := check.Check(); err != nil {
+			return "", err
+		}
+	} else {
+		again, err := promptPassphrase(fd, i18n.T("🔑 Repeat the passphrase for the new secrets file: "))
+		if err != nil {
+			return "", err
+		}
+		if again != typed {
+			return "", i18n.Errorf("the passphrases do not match")
+		}
+	}
+
+	passphrase = typed
+	return passphrase, nil
+}
+
+// promptPassphrase asks for a passphrase on the terminal without echoing it
+func promptPassphrase(fd int, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	typed, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", i18n.Errorf("failed to read passphrase: %w", err)
+	}
+	return string(typed), nil
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package config
+
+import "golang.org/x/sys/unix"
+
+// writable reports whether the current user may create files in dir.
+// access(2) also reports read-only mounts.
+func writable(dir string) bool {
+	return unix.Access(dir, unix.W_OK) == nil
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package config
+
+import "os"
+
+// writable reports whether dir is writable according to its attributes
+func writable(dir string) bool {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return false
+	}
+	return info.Mode().Perm()&0200 != 0
+}
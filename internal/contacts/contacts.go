@@ -0,0 +1,177 @@
+package contacts
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/phone"
+)
+
+const (
+	// contactsFileName is the name of the local contacts file
+	contactsFileName = "contacts.json"
+	// defaultFilePerms are the default permissions for the contacts file
+	defaultFilePerms = 0600
+)
+
+// Contact is a named mobile number, optionally in groups such as "customers"
+type Contact struct {
+	Name   string   `json:"name"`
+	Mobile string   `json:"mobile"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// InGroup reports whether the contact is in the named group
+func (c Contact) InGroup(group string) bool {
+	for _, g := range c.Groups {
+		if strings.EqualFold(g, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// Book is the local address book, keyed by mobile number
+type Book struct {
+	path     string
+	contacts map[string]Contact
+}
+
+// Load reads the contacts from the configuration directory
+func Load() (*Book, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Book{
+		path:     filepath.Join(dir, contactsFileName),
+		contacts: make(map[string]Contact),
+	}
+
+	data, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("failed to read contacts: %w", err)
+	}
+
+	var contacts []Contact
+	if err := json.Unmarshal(data, &contacts); err != nil {
+		return nil, i18n.Errorf("failed to unmarshal contacts: %w", err)
+	}
+	for _, c := range contacts {
+		b.contacts[c.Mobile] = c
+	}
+
+	return b, nil
+}
+
+// Set adds a contact, or renames and regroups the contact with the same number
+func (b *Book) Set(name, mobile string, groups []string) (Contact, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Contact{}, i18n.Errorf("contact name is required")
+	}
+	number, err := phone.Normalize(mobile)
+	if err != nil {
+		return Contact{}, err
+	}
+
+	var cleaned []string
+	for _, g := range groups {
+		if g = strings.TrimSpace(g); g != "" {
+			cleaned = append(cleaned, g)
+		}
+	}
+
+	c := Contact{Name: name, Mobile: number, Groups: cleaned}
+	b.contacts[number] = c
+	return c, nil
+}
+
+// Remove deletes the contacts with a mobile number or name and reports how many were removed
+func (b *Book) Remove(nameOrMobile string) int {
+	if number, err := phone.Normalize(nameOrMobile); err == nil {
+		if _, exists := b.contacts[number]; exists {
+			delete(b.contacts, number)
+			return 1
+		}
+		return 0
+	}
+
+	removed := 0
+	for number, c := range b.contacts {
+		if strings.EqualFold(c.Name, nameOrMobile) {
+			delete(b.contacts, number)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Contacts returns all contacts sorted by name
+func (b *Book) Contacts() []Contact {
+	contacts := make([]Contact, 0, len(b.contacts))
+	for _, c := range b.contacts {
+		contacts = append(contacts, c)
+	}
+	sort.Slice(contacts, func(i, j int) bool {
+		if contacts[i].Name != contacts[j].Name {
+			return contacts[i].Name < contacts[j].Name
+		}
+		return contacts[i].Mobile < contacts[j].Mobile
+	})
+	return contacts
+}
+
+// Group returns the contacts in the named group, sorted by name
+func (b *Book) Group(group string) []Contact {
+	var members []Contact
+	for _, c := range b.Contacts() {
+		if c.InGroup(group) {
+			members = append(members, c)
+		}
+	}
+	return members
+}
+
+// Groups returns the names of all groups in alphabetical order
+func (b *Book) Groups() []string {
+	seen := make(map[string]bool)
+	var groups []string
+	for _, c := range b.contacts {
+		for _, g := range c.Groups {
+			if key := strings.ToLower(g); !seen[key] {
+				seen[key] = true
+				groups = append(groups, g)
+			}
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// Save writes the contacts to disk
+func (b *Book) Save() error {
+	if config.ReadOnly() {
+		return config.ErrReadOnly
+	}
+
+	data, err := json.MarshalIndent(b.Contacts(), "", "  ")
+	if err != nil {
+		return i18n.Errorf("failed to marshal contacts: %w", err)
+	}
+
+	if err := os.WriteFile(b.path, data, defaultFilePerms); err != nil {
+		return i18n.Errorf("failed to write contacts: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package history
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// creditRetention is how long credit samples are kept
+	creditRetention = 90 * 24 * time.Hour
+	// creditSampleGap is how often an unchanged credit is sampled again
+	creditSampleGap = time.Hour
+	// burnRateWindow is the period the burn rate is averaged over
+	burnRateWindow = 7 * 24 * time.Hour
+	// minBurnRateSpan is the shortest period a burn rate is estimated from
+	minBurnRateSpan = time.Hour
+)
+
+// creditBucket holds one JSON-encoded CreditSample per reading, keyed by big-endian sequence
+var creditBucket = []byte("credit")
+
+// CreditSample is the account credit of a profile at a point in time
+type CreditSample struct {
+	Timestamp time.Time `json:"timestamp"`
+	Profile   string    `json:"profile"`
+	Credit    float64   `json:"credit"`
+}
+
+// AddCredit stores a credit reading of a profile. A reading equal to the last
+// one is skipped unless that is older than creditSampleGap, and samples older
+// than creditRetention are removed.
+func (s *Store) AddCredit(profile string, credit float64) error {
+	if profile == "" {
+		profile = defaultProfile
+	}
+	now := time.Now()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(creditBucket)
+		if err != nil {
+			return err
+		}
+
+		var stale [][]byte
+		var last *CreditSample
+		err = b.ForEach(func(k, v []byte) error {
+			var sample CreditSample
+			if err := json.Unmarshal(v, &sample); err != nil {
+				return i18n.Errorf("failed to unmarshal credit sample: %w", err)
+			}
+			if now.Sub(sample.Timestamp) > creditRetention {
+				stale = append(stale, k)
+			} else if sample.Profile == profile {
+				last = &sample
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		// Keys must not be deleted while iterating
+		for _, k := range stale {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		if last != nil && last.Credit == credit && now.Sub(last.Timestamp) < creditSampleGap {
+			return nil
+		}
+
+		id, err := b.NextSequence()
+		if err != nil {
+			return i18n.Errorf("failed to allocate history id: %w", err)
+		}
+		data, err := json.Marshal(CreditSample{Timestamp: now, Profile: profile, Credit: credit})
+		if err != nil {
+			return i18n.Errorf("failed to marshal credit sample: %w", err)
+		}
+		return b.Put(itob(id), data)
+	})
+}
+
+// CreditHistory returns the credit samples of a profile taken since the given time, oldest first
+func (s *Store) CreditHistory(profile string, since time.Time) ([]CreditSample, error) {
+	if profile == "" {
+		profile = defaultProfile
+	}
+
+	var samples []CreditSample
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(creditBucket)
+		if b == nil {
+			// Nothing was recorded yet, e.g. in a read-only history
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			var sample CreditSample
+			if err := json.Unmarshal(v, &sample); err != nil {
+				return i18n.Errorf("failed to unmarshal credit sample: %w", err)
+			}
+			if sample.Profile == profile && !sample.Timestamp.Before(since) {
+				samples = append(samples, sample)
+			}
+			return nil
+		})
+	})
+	return samples, err
+}
+
+// RecordCredit opens the history database, stores a credit reading and
+// returns the profile's samples of the last creditRetention. In read-only
+// mode nothing is stored, but an existing history is still read.
+func RecordCredit(profile string, credit float64) ([]CreditSample, error) {
+	store, err := Open()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	if !config.ReadOnly() {
+		if err := store.AddCredit(profile, credit); err != nil {
+			return nil, err
+		}
+	}
+	return store.CreditHistory(profile, time.Now().Add(-creditRetention))
+}
+
+// BurnRate returns the credit used per day, averaged over the samples of the
+// last burnRateWindow. Increases are top-ups and do not count as use. ok is
+// false when the samples span too short a period for an estimate.
+func BurnRate(samples []CreditSample) (perDay float64, ok bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+
+	since := samples[len(samples)-1].Timestamp.Add(-burnRateWindow)
+	var used float64
+	var first *CreditSample
+	for i := range samples {
+		if samples[i].Timestamp.Before(since) {
+			continue
+		}
+		if first == nil {
+			first = &samples[i]
+			continue
+		}
+		if drop := samples[i-1].Credit - samples[i].Credit; drop > 0 {
+			used += drop
+		}
+	}
+
+	if first == nil {
+		return 0, false
+	}
+	span := samples[len(samples)-1].Timestamp.Sub(first.Timestamp)
+	if span < minBurnRateSpan {
+		return 0, false
+	}
+	return used / (span.Hours() / 24), true
+}
@@ -0,0 +1,65 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBurnRate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	// at returns a sample taken hours before now
+	at := func(hoursAgo int, credit float64) CreditSample {
+		return CreditSample{Timestamp: now.Add(-time.Duration(hoursAgo) * time.Hour), Profile: "default", Credit: credit}
+	}
+
+	tests := []struct {
+		name    string
+		samples []CreditSample
+		want    float64
+		wantOK  bool
+	}{
+		{name: "no samples", samples: nil},
+		{name: "one sample", samples: []CreditSample{at(0, 100)}},
+		{
+			name:    "steady use",
+			samples: []CreditSample{at(48, 100), at(24, 80), at(0, 60)},
+			want:    20,
+			wantOK:  true,
+		},
+		{
+			name:    "top-ups are not use",
+			samples: []CreditSample{at(48, 100), at(36, 50), at(24, 150), at(0, 140)},
+			want:    30,
+			wantOK:  true,
+		},
+		{
+			name:    "no use",
+			samples: []CreditSample{at(24, 100), at(0, 100)},
+			want:    0,
+			wantOK:  true,
+		},
+		{
+			name:    "samples before the window are ignored",
+			samples: []CreditSample{at(240, 1000), at(48, 100), at(24, 90), at(0, 80)},
+			want:    10,
+			wantOK:  true,
+		},
+		{
+			name:    "too short a span",
+			samples: []CreditSample{at(0, 100), {Timestamp: now.Add(30 * time.Minute), Credit: 90}},
+		},
+		{
+			name:    "only one sample in the window",
+			samples: []CreditSample{at(400, 100), at(0, 90)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := BurnRate(tt.samples)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("BurnRate() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
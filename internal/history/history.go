@@ -0,0 +1,317 @@
+package history
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/phone"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// historyFileName is the name of the local history database
+	historyFileName = "history.db"
+	// defaultFilePerms are the default permissions for the history database
+	defaultFilePerms = 0600
+	// openTimeout is how long to wait for another process holding the database lock
+	openTimeout = 2 * time.Second
+	// defaultProfile is recorded when the caller does not name a profile
+	defaultProfile = "default"
+)
+
+// sendsBucket holds one JSON-encoded Record per send, keyed by big-endian ID
+var sendsBucket = []byte("sends")
+
+// Origin identifies where a send was made from
+type Origin string
+
+const (
+	OriginCLI   Origin = "cli"
+	OriginTUI   Origin = "tui"
+	OriginBatch Origin = "batch"
+)
+
+// Record is a single stored send
+type Record struct {
+	ID          uint64     `json:"id"`
+	Timestamp   time.Time  `json:"timestamp"`
+	Profile     string     `json:"profile"`
+	LineNumber  int64      `json:"lineNumber"`
+	MessageText string     `json:"messageText"`
+	Recipients  []string   `json:"recipients"`
+	PackID      string     `json:"packId"`
+	MessageIDs  []int32    `json:"messageIds"`
+	Cost        float64    `json:"cost"`
+	Origin      Origin     `json:"origin"`
+	Tags        []string   `json:"tags,omitempty"`
+	Deliveries  []Delivery `json:"deliveries,omitempty"`
+	SyncedAt    time.Time  `json:"syncedAt,omitempty"`
+	SendAt      time.Time  `json:"sendAt,omitempty"`
+}
+
+// Delivery is the last known delivery outcome of one message of a record
+type Delivery struct {
+	MessageID        int32             `json:"messageId"`
+	Mobile           string            `json:"mobile"`
+	State            api.DeliveryState `json:"deliveryState"`
+	DeliveryDateTime int64             `json:"deliveryDateTime,omitempty"`
+}
+
+// Filter narrows down the records returned by List
+type Filter struct {
+	Since     time.Time
+	Until     time.Time
+	Recipient string
+	Text      string
+	Tag       string
+	Limit     int
+}
+
+// Store is the local sent-message history database
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens the history database in the configuration directory
+func Open() (*Store, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, historyFileName)
+
+	// In read-only mode an existing history can still be read
+	if config.ReadOnly() {
+		if _, err := os.Stat(path); err != nil {
+			return nil, config.ErrReadOnly
+		}
+		db, err := bolt.Open(path, defaultFilePerms, &bolt.Options{Timeout: openTimeout, ReadOnly: true})
+		if err != nil {
+			return nil, i18n.Errorf("failed to open history database: %w", err)
+		}
+		return &Store{db: db}, nil
+	}
+
+	db, err := bolt.Open(path, defaultFilePerms, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, i18n.Errorf("failed to open history database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(sendsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, i18n.Errorf("failed to initialize history database: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the history database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Add stores a new record and assigns its ID
+func (s *Store) Add(r *Record) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(sendsBucket)
+
+		id, err := b.NextSequence()
+		if err != nil {
+			return i18n.Errorf("failed to allocate history id: %w", err)
+		}
+		r.ID = id
+		if r.Timestamp.IsZero() {
+			r.Timestamp = time.Now()
+		}
+		if r.Profile == "" {
+			r.Profile = defaultProfile
+		}
+
+		data, err := json.Marshal(r)
+		if err != nil {
+			return i18n.Errorf("failed to marshal history record: %w", err)
+		}
+		return b.Put(itob(id), data)
+	})
+}
+
+// Update replaces stored records with the given versions in a single transaction
+func (s *Store) Update(records ...*Record) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(sendsBucket)
+		for _, r := range records {
+			if b.Get(itob(r.ID)) == nil {
+				return i18n.Errorf("history record %d not found", r.ID)
+			}
+			data, err := json.Marshal(r)
+			if err != nil {
+				return i18n.Errorf("failed to marshal history record: %w", err)
+			}
+			if err := b.Put(itob(r.ID), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Get returns the record with the given ID
+func (s *Store) Get(id uint64) (*Record, error) {
+	var r Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(sendsBucket).Get(itob(id))
+		if data == nil {
+			return i18n.Errorf("history record %d not found", id)
+		}
+		return json.Unmarshal(data, &r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// FindByPackID returns the record for a pack ID
+func (s *Store) FindByPackID(packID string) (*Record, error) {
+	var found *Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(sendsBucket).ForEach(func(k, v []byte) error {
+			var r Record
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			if r.PackID == packID {
+				found = &r
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, i18n.Errorf("no history record for pack %s", packID)
+	}
+	return found, nil
+}
+
+// List returns the records matching the filter, newest first
+func (s *Store) List(f Filter) ([]Record, error) {
+	var records []Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(sendsBucket).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			var r Record
+			if err := json.Unmarshal(v, &r); err != nil {
+				return i18n.Errorf("failed to unmarshal history record: %w", err)
+			}
+			if !f.matches(&r) {
+				continue
+			}
+			records = append(records, r)
+			if f.Limit > 0 && len(records) >= f.Limit {
+				break
+			}
+		}
+		return nil
+	})
+	return records, err
+}
+
+// matches reports whether a record passes the filter
+func (f Filter) matches(r *Record) bool {
+	if !f.Since.IsZero() && r.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && r.Timestamp.After(f.Until) {
+		return false
+	}
+	if f.Text != "" && !strings.Contains(strings.ToLower(r.MessageText), strings.ToLower(f.Text)) {
+		return false
+	}
+	if f.Recipient != "" && !r.HasRecipient(f.Recipient) {
+		return false
+	}
+	if f.Tag != "" && !r.HasTag(f.Tag) {
+		return false
+	}
+	return true
+}
+
+// HasRecipient reports whether the record was sent to the given mobile
+func (r *Record) HasRecipient(mobile string) bool {
+	want, err := phone.Normalize(mobile)
+	if err != nil {
+		want = mobile
+	}
+	for _, recipient := range r.Recipients {
+		got, err := phone.Normalize(recipient)
+		if err != nil {
+			got = recipient
+		}
+		if got == want {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary counts the record's messages by their last known delivery outcome.
+// Messages without a stored report are counted as pending.
+func (r *Record) Summary() api.DeliverySummary {
+	var s api.DeliverySummary
+	for _, d := range r.Messages() {
+		s.Count(d.State)
+	}
+	return s
+}
+
+// IsPending reports whether some of the record's messages have no final delivery state yet
+func (r *Record) IsPending() bool {
+	return r.PackID != "" && r.Summary().Pending > 0
+}
+
+// HasTag reports whether the record carries the given tag
+func (r *Record) HasTag(tag string) bool {
+	for _, t := range r.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Save opens the history database, stores a single record and closes it again.
+// Nothing is recorded in read-only mode.
+func Save(r *Record) error {
+	if config.ReadOnly() {
+		return nil
+	}
+
+	store, err := Open()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	return store.Add(r)
+}
+
+// itob encodes an ID as a sortable big-endian key
+func itob(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
@@ -0,0 +1,81 @@
+package history
+
+import (
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/phone"
+)
+
+// Reconcile fetches the delivery reports of a record's messages and stores them
+// in r.Deliveries. The pack report is used first; messages it does not list are
+// looked up one by one through the message report endpoint.
+func Reconcile(client *api.Client, r *Record) error {
+	resp, err := client.GetPackReport(r.PackID)
+	if err != nil {
+		return i18n.Errorf("error getting pack report: %w", err)
+	}
+	if !resp.IsSuccess() {
+		return i18n.Errorf("API error: %s", resp.GetStatusMessage())
+	}
+
+	reports := make(map[int32]api.ReportSendMessageResponse, len(resp.Data.Messages))
+	for _, msg := range resp.Data.Messages {
+		reports[msg.MessageId] = msg
+	}
+
+	deliveries := make([]Delivery, 0, len(r.MessageIDs))
+	for _, id := range r.MessageIDs {
+		report, ok := reports[id]
+		if !ok {
+			msgResp, err := client.GetMessageReport(id)
+			if err != nil || !msgResp.IsSuccess() {
+				// Keep the previous state; the next sync will try again
+				deliveries = append(deliveries, r.delivery(id))
+				continue
+			}
+			report = msgResp.Data
+		}
+
+		deliveries = append(deliveries, Delivery{
+			MessageID:        id,
+			Mobile:           phone.FromInt(report.Mobile),
+			State:            report.DeliveryState,
+			DeliveryDateTime: report.DeliveryDateTime,
+		})
+	}
+
+	r.Deliveries = deliveries
+	r.SyncedAt = time.Now()
+	return nil
+}
+
+// delivery returns the stored delivery of a message, or a pending one
+func (r *Record) delivery(messageID int32) Delivery {
+	for _, d := range r.Deliveries {
+		if d.MessageID == messageID {
+			return d
+		}
+	}
+	return Delivery{MessageID: messageID, State: api.DeliveryStatePending}
+}
+
+// Messages returns the last known delivery of every message of the record.
+// Messages without a stored report are pending and take their mobile from the
+// recipient at the same position, since a send returns one ID per recipient.
+func (r *Record) Messages() []Delivery {
+	messages := make([]Delivery, len(r.MessageIDs))
+	for i, id := range r.MessageIDs {
+		d := r.delivery(id)
+		if d.Mobile == "" && len(r.Recipients) == len(r.MessageIDs) {
+			mobile, err := phone.Normalize(r.Recipients[i])
+			if err != nil {
+				mobile = r.Recipients[i]
+			}
+			d.Mobile = mobile
+		}
+		messages[i] = d
+	}
+	return messages
+}
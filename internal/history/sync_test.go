@@ -0,0 +1,80 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+)
+
+func TestRecordMessages(t *testing.T) {
+	tests := []struct {
+		name   string
+		record Record
+		want   []Delivery
+	}{
+		{
+			name:   "no reports yet",
+			record: Record{Recipients: []string{"+989121110000", "09121110001"}, MessageIDs: []int32{1, 2}},
+			want: []Delivery{
+				{MessageID: 1, Mobile: "09121110000", State: api.DeliveryStatePending},
+				{MessageID: 2, Mobile: "09121110001", State: api.DeliveryStatePending},
+			},
+		},
+		{
+			name: "stored reports",
+			record: Record{
+				Recipients: []string{"09121110000", "09121110001"},
+				MessageIDs: []int32{1, 2},
+				Deliveries: []Delivery{{MessageID: 2, Mobile: "09121110001", State: api.DeliveryStateFailed}},
+			},
+			want: []Delivery{
+				{MessageID: 1, Mobile: "09121110000", State: api.DeliveryStatePending},
+				{MessageID: 2, Mobile: "09121110001", State: api.DeliveryStateFailed},
+			},
+		},
+		{
+			name: "recipients not matching the messages",
+			record: Record{
+				Recipients: []string{"09121110000"},
+				MessageIDs: []int32{1, 2},
+			},
+			want: []Delivery{
+				{MessageID: 1, State: api.DeliveryStatePending},
+				{MessageID: 2, State: api.DeliveryStatePending},
+			},
+		},
+		{
+			name:   "no messages",
+			record: Record{Recipients: []string{"09121110000"}},
+			want:   []Delivery{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.record.Messages(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Messages() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordSummary(t *testing.T) {
+	record := Record{
+		Recipients: []string{"09121110000", "09121110001", "09121110002", "09121110003"},
+		MessageIDs: []int32{1, 2, 3, 4},
+		Deliveries: []Delivery{
+			{MessageID: 1, State: api.DeliveryStateDelivered},
+			{MessageID: 2, State: api.DeliveryStateBlacklisted},
+			{MessageID: 3, State: api.DeliveryStateAtOperator},
+			// A report for a message the record does not list is not counted
+			{MessageID: 9, State: api.DeliveryStateDelivered},
+		},
+	}
+
+	want := api.DeliverySummary{Total: 4, Delivered: 1, Failed: 1, Pending: 2}
+	if got := record.Summary(); got != want {
+		t.Errorf("Summary() = %+v, want %+v", got, want)
+	}
+}
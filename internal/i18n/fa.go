@@ -0,0 +1,715 @@
+package i18n
+
+// persian translates the English messages to Persian. Messages without an
+// entry are shown in English.
+var persian = map[string]string{
+	// Root command and global flags
+	"SMS.ir CLI - A simple message can connect worlds with a single command": "خط فرمان SMS.ir - یک پیام ساده با یک فرمان دنیاها را به هم وصل می‌کند",
+	"SMS.ir CLI is a professional command-line tool for interacting with SMS.ir APIs.\n\nThis tool provides both interactive and command-line interfaces for:\n• Sending SMS messages\n• Interactive dashboard with real-time updates\n• Checking setting\n\nQuick Start:\n  smsir config                    # Set API credentials\n  smsir send                      # Send SMS message\n  smsir credit                    # Check your balance\n  smsir lines                     # View available lines\n  smsir menu                      # Launch interactive menu": "خط فرمان SMS.ir ابزاری حرفه‌ای برای کار با APIهای SMS.ir است.\n\nاین ابزار هم رابط تعاملی و هم رابط خط فرمان دارد برای:\n• ارسال پیامک\n• داشبورد تعاملی با به‌روزرسانی زنده\n• بررسی تنظیمات\n\nشروع سریع:\n  smsir config                    # تنظیم کلید API\n  smsir send                      # ارسال پیامک\n  smsir credit                    # دیدن اعتبار\n  smsir lines                     # دیدن خطوط\n  smsir menu                      # اجرای منوی تعاملی",
+	"show more details": "نمایش جزئیات بیشتر",
+	"config file to use (default from SMSIR_CONFIG or the config directory)": "فایل تنظیمات (پیش‌فرض از SMSIR_CONFIG یا پوشه تنظیمات)",
+	"profile to use (default from config or SMSIR_PROFILE)":                  "پروفایل مورد استفاده (پیش‌فرض از تنظیمات یا SMSIR_PROFILE)",
+	"output format: table, json, yaml, csv or tsv":                           "قالب خروجی: table، json، yaml، csv یا tsv",
+	"print only values, e.g. the bare credit number":                         "فقط مقدارها را چاپ کن، مثلاً خود عدد اعتبار",
+	"language: en or fa (default from SMSIR_LANG or the locale)":             "زبان: en یا fa (پیش‌فرض از SMSIR_LANG یا locale)",
+	"digits for numbers: latin or persian (default from SMSIR_DIGITS)":       "ارقام اعداد: latin یا persian (پیش‌فرض از SMSIR_DIGITS)",
+
+	// Cobra help
+	"Usage:":                  "استفاده:",
+	"Aliases:":                "نام‌های دیگر:",
+	"Examples:":               "مثال‌ها:",
+	"Available Commands:":     "فرمان‌های موجود:",
+	"Additional Commands:":    "فرمان‌های دیگر:",
+	"Global Flags:":           "گزینه‌های سراسری:",
+	"Flags:":                  "گزینه‌ها:",
+	"Additional help topics:": "موضوعات راهنمای دیگر:",
+	"Use \"%s [command] --help\" for more information about a command.": "برای اطلاعات بیشتر درباره هر فرمان \"%s [command] --help\" را اجرا کنید.",
+	"help for %s":            "راهنمای %s",
+	"Help about any command": "راهنمای هر فرمان",
+	"Help provides help for any command in the application.\nSimply type smsir help [path to command] for full details.": "راهنمای هر فرمان برنامه.\nبرای جزئیات کامل smsir help [مسیر فرمان] را اجرا کنید.",
+	"Error:": "خطا:",
+	"yes":    "بله",
+
+	// config
+	"Configuration management":                     "مدیریت تنظیمات",
+	"Manage API Key and line number configuration": "مدیریت کلید API و شماره خط",
+	"Set configuration values":                     "تنظیم مقدارها",
+	"Set a configuration key of the active profile, or the API Key and line number with flags.\n\nExamples:\n  smsir config set line_number 30001234\n  smsir config set dashboard.refresh 30\n  smsir config set optout.keywords \"STOP,لغو\"\n  smsir config set --api-key KEY --line 30001234\n\nRun 'smsir config list' to see every key.": "یک کلید تنظیمات پروفایل فعال را تنظیم می‌کند، یا کلید API و شماره خط را با گزینه‌ها.\n\nمثال‌ها:\n  smsir config set line_number 30001234\n  smsir config set dashboard.refresh 30\n  smsir config set optout.keywords \"STOP,لغو\"\n  smsir config set --api-key KEY --line 30001234\n\nبرای دیدن همه کلیدها 'smsir config list' را اجرا کنید.",
+	"expected a key and a value":                         "یک کلید و یک مقدار لازم است",
+	"specify a key and a value, or --api-key and --line": "یک کلید و مقدار، یا --api-key و --line را مشخص کنید",
+	"invalid configuration: %w":                          "تنظیمات نامعتبر است: %w",
+	"error saving configuration: %w":                     "خطا در ذخیره تنظیمات: %w",
+	"✅ Configuration saved successfully (profile %q)\n":  "✅ تنظیمات با موفقیت ذخیره شد (پروفایل %q)\n",
+	"Print a configuration value":                        "چاپ مقدار یک کلید تنظیمات",
+	"Print the effective value of a configuration key, after the project file\nand environment variables were applied. Secrets are masked unless --reveal is given.": "مقدار مؤثر یک کلید تنظیمات را پس از اعمال فایل پروژه و متغیرهای محیطی چاپ می‌کند.\nمقدارهای محرمانه پوشانده می‌شوند، مگر با --reveal.",
+	"Restore the default value of a configuration key":                          "بازگرداندن مقدار پیش‌فرض یک کلید تنظیمات",
+	"Restore the default value of a configuration key in the active profile":    "مقدار پیش‌فرض یک کلید تنظیمات را در پروفایل فعال بازمی‌گرداند",
+	"✅ %s restored to its default (profile %q)\n":                               "✅ %s به مقدار پیش‌فرض برگشت (پروفایل %q)\n",
+	"List all configuration keys and values":                                    "فهرست همه کلیدها و مقدارهای تنظیمات",
+	"List every configuration key with its effective value; secrets are masked": "همه کلیدهای تنظیمات را با مقدار مؤثرشان نشان می‌دهد؛ مقدارهای محرمانه پوشانده می‌شوند",
+	"KEY":                               "کلید",
+	"VALUE":                             "مقدار",
+	"SOURCE":                            "منبع",
+	"DESCRIPTION":                       "توضیح",
+	"⚙️  Configuration (profile %q):\n": "⚙️  تنظیمات (پروفایل %q):\n",
+	"Show current configuration":        "نمایش تنظیمات فعلی",
+	"Show current configuration including API Key and line number": "نمایش تنظیمات فعلی، از جمله کلید API و شماره خط",
+	"error finding configuration file: %w":                         "خطا در یافتن فایل تنظیمات: %w",
+	"Profile: %s (%s)\n":                                           "پروفایل: %s (%s)\n",
+	"API Key: %s (%s)\n":                                           "کلید API: %s (%s)\n",
+	"Line Number: %s (%s)\n":                                       "شماره خط: %s (%s)\n",
+	"Base URL: %s (%s)\n":                                          "نشانی پایه: %s (%s)\n",
+	"Config File: %s\n":                                            "فایل تنظیمات: %s\n",
+	"Project File: %s\n":                                           "فایل پروژه: %s\n",
+	"Mode: read-only (nothing is written to disk)":                 "حالت: فقط‌خواندنی (چیزی روی دیسک نوشته نمی‌شود)",
+	"Validate configuration":                                       "اعتبارسنجی تنظیمات",
+	"Validate current configuration.\n\nWith --online the configuration is also checked against the live account:\nthe base URL must be reachable, the API key accepted and the line number one\nof the account's lines. The local clock is compared with the server's.": "تنظیمات فعلی را اعتبارسنجی می‌کند.\n\nبا --online تنظیمات با حساب واقعی هم بررسی می‌شود: نشانی پایه باید در دسترس باشد،\nکلید API پذیرفته شود و شماره خط یکی از خطوط حساب باشد. ساعت سیستم هم با ساعت سرور مقایسه می‌شود.",
+	"✅ Configuration is valid": "✅ تنظیمات معتبر است",
+	"CHECK":                    "بررسی",
+	"STATUS":                   "وضعیت",
+	"DETAIL":                   "جزئیات",
+	"configuration does not work with the account": "تنظیمات با حساب کار نمی‌کند",
+	"Show or change where API keys are stored":     "نمایش یا تغییر محل نگهداری کلیدهای API",
+	"Show or change the secret backend that stores API keys.\n\n  keyring  OS keyring (Keychain, Credential Manager, Secret Service)\n  file     file encrypted with a passphrase (asked for or read from SMSIR_PASSPHRASE)\n  env      nothing is stored; the key is read from SMSIR_API_KEY\n\nChanging the backend moves every stored key to the new one.": "محل نگهداری کلیدهای API را نشان می‌دهد یا تغییر می‌دهد.\n\n  keyring  کلیدساز سیستم‌عامل (Keychain، Credential Manager، Secret Service)\n  file     فایل رمزشده با عبارت عبور (پرسیده می‌شود یا از SMSIR_PASSPHRASE خوانده می‌شود)\n  env      چیزی ذخیره نمی‌شود؛ کلید از SMSIR_API_KEY خوانده می‌شود\n\nبا تغییر محل، همه کلیدهای ذخیره‌شده به محل جدید منتقل می‌شوند.",
+	"error loading configuration: %w":                                           "خطا در بارگذاری تنظیمات: %w",
+	"error changing secret backend: %w":                                         "خطا در تغییر محل نگهداری کلیدها: %w",
+	"✅ API keys are no longer stored; set SMSIR_API_KEY before running smsir\n": "✅ کلیدهای API دیگر ذخیره نمی‌شوند؛ پیش از اجرای smsir مقدار SMSIR_API_KEY را تنظیم کنید\n",
+	"✅ API keys are now stored in %s\n":                                         "✅ کلیدهای API اکنون در %s نگهداری می‌شوند\n",
+	"API Key from SMS.ir panel":                                                 "کلید API از پنل SMS.ir",
+	"Line number":                                                               "شماره خط",
+	"print secrets unmasked":                                                    "مقدارهای محرمانه را بدون پوشش چاپ کن",
+	"also check the key, line, reachability and clock against the live account": "کلید، خط، دسترسی و ساعت را با حساب واقعی هم بررسی کن",
+
+	// config doctor
+	"Check configuration files and secret storage": "بررسی فایل‌های تنظیمات و محل نگهداری کلیدها",
+	"Check the configuration directory for problems:\n\n• files or directories readable by other users\n• API keys still stored in plaintext in config.json\n• a secret backend that cannot be reached or does not hold the API key\n\nUse --fix to tighten permissions and move plaintext keys into the secret backend.": "پوشه تنظیمات را از نظر این مشکلات بررسی می‌کند:\n\n• فایل‌ها یا پوشه‌هایی که کاربران دیگر می‌توانند بخوانند\n• کلیدهای API که هنوز به صورت متن ساده در config.json هستند\n• محل نگهداری کلیدی که در دسترس نیست یا کلید API را ندارد\n\nبا --fix دسترسی‌ها محدود و کلیدهای متن ساده به محل نگهداری کلیدها منتقل می‌شوند.",
+	"error finding configuration directory: %w":                    "خطا در یافتن پوشه تنظیمات: %w",
+	"error reading configuration directory: %w":                    "خطا در خواندن پوشه تنظیمات: %w",
+	"permissions %04o":                                             "دسترسی %04o",
+	"failed to fix permissions: %v":                                "اصلاح دسترسی ناموفق بود: %v",
+	"permissions fixed %04o → %04o":                                "دسترسی اصلاح شد %04o → %04o",
+	"permissions %04o allow access by other users":                 "دسترسی %04o به کاربران دیگر اجازه دسترسی می‌دهد",
+	"secret backend":                                               "محل نگهداری کلیدها",
+	"OS keyring":                                                   "کلیدساز سیستم‌عامل",
+	"OS keyring is not available; run 'smsir config backend file'": "کلیدساز سیستم‌عامل در دسترس نیست؛ 'smsir config backend file' را اجرا کنید",
+	"encrypted file":                                               "فایل رمزشده",
+	"environment only (SMSIR_API_KEY)":                             "فقط متغیر محیطی (SMSIR_API_KEY)",
+	"unknown backend %q":                                           "محل نگهداری ناشناخته %q",
+	"plaintext keys":                                               "کلیدهای متن ساده",
+	"failed to move keys: %v":                                      "انتقال کلیدها ناموفق بود: %v",
+	"keys moved to %s":                                             "کلیدها به %s منتقل شدند",
+	"config.json holds API keys in plaintext":                      "config.json کلیدهای API را به صورت متن ساده دارد",
+	"none":                    "ندارد",
+	"api key":                 "کلید API",
+	"readable for profile %q": "برای پروفایل %q خواناست",
+	"\n💡 Run 'smsir config doctor --fix' to repair what can be fixed automatically\n": "\n💡 برای رفع خودکار مشکلات 'smsir config doctor --fix' را اجرا کنید\n",
+	"%d problem(s) found": "%d مشکل پیدا شد",
+	"tighten permissions and move plaintext keys into the secret backend": "دسترسی‌ها را محدود و کلیدهای متن ساده را به محل نگهداری کلیدها منتقل کن",
+
+	// config edit
+	"Edit the active profile in your editor": "ویرایش پروفایل فعال در ویرایشگر",
+	"Open the active profile in $VISUAL or $EDITOR as JSON. The result is validated\nbefore it is saved; on errors you can edit again or cancel.\n\nThe API key is not shown; add an \"api_key\" entry to replace it.": "پروفایل فعال را به صورت JSON در $VISUAL یا $EDITOR باز می‌کند. نتیجه پیش از ذخیره\nاعتبارسنجی می‌شود؛ در صورت خطا می‌توانید دوباره ویرایش یا لغو کنید.\n\nکلید API نمایش داده نمی‌شود؛ برای جایگزینی آن یک مقدار \"api_key\" اضافه کنید.",
+	"error preparing configuration: %w":      "خطا در آماده‌سازی تنظیمات: %w",
+	"error creating temporary file: %w":      "خطا در ساخت فایل موقت: %w",
+	"error writing temporary file: %w":       "خطا در نوشتن فایل موقت: %w",
+	"error reading edited configuration: %w": "خطا در خواندن تنظیمات ویرایش‌شده: %w",
+	"Edit again? [Y/n] ":                     "دوباره ویرایش شود؟ [Y/n] ",
+	"configuration not changed":              "تنظیمات تغییر نکرد",
+	"invalid JSON: %w":                       "JSON نامعتبر: %w",
+	"error running editor %q: %w":            "خطا در اجرای ویرایشگر %q: %w",
+
+	// credit
+	"Show current credit balance":                   "نمایش اعتبار فعلی",
+	"Show current credit balance of SMS.ir account": "نمایش اعتبار فعلی حساب SMS.ir",
+	"error getting credit: %w":                      "خطا در دریافت اعتبار: %w",
+	"API error: %s":                                 "خطای API: %s",
+	"CREDIT":                                        "اعتبار",
+	"💰 Current Credit: %.2f SMS\n":                  "💰 اعتبار فعلی: %.2f پیامک\n",
+
+	// history
+	"Sent message history": "تاریخچه پیام‌های ارسالی",
+	"Browse the local history of every message sent from the CLI, the interactive UI and batch jobs": "مرور تاریخچه محلی همه پیام‌هایی که از خط فرمان، رابط تعاملی و کارهای دسته‌ای ارسال شده‌اند",
+	"List sent messages": "فهرست پیام‌های ارسالی",
+	"List sent messages, newest first, optionally filtered by date, recipient, text or tag": "فهرست پیام‌های ارسالی از جدیدترین، با امکان فیلتر بر اساس تاریخ، گیرنده، متن یا برچسب",
+	"Search sent messages by text": "جستجوی پیام‌های ارسالی بر اساس متن",
+	"Search sent messages whose text contains the given phrase (case-insensitive)": "جستجوی پیام‌های ارسالی که متنشان عبارت داده‌شده را دارد (بدون حساسیت به حروف بزرگ و کوچک)",
+	"Show a single sent message":                                         "نمایش یک پیام ارسالی",
+	"Show all stored details of a sent message by history ID or pack ID": "نمایش همه جزئیات ذخیره‌شده یک پیام با شناسه تاریخچه یا شناسه بسته",
+	"error opening history: %w":                                          "خطا در باز کردن تاریخچه: %w",
+	"Fetch delivery status for pending messages":                         "دریافت وضعیت تحویل پیام‌های در انتظار",
+	"Look up the delivery reports of messages that have not reached a final state yet\nand store the outcome in the local history, so delivery rates can be shown offline.": "گزارش تحویل پیام‌هایی را که هنوز به وضعیت نهایی نرسیده‌اند دریافت می‌کند\nو نتیجه را در تاریخچه محلی ذخیره می‌کند تا نرخ تحویل بدون اتصال هم نمایش داده شود.",
+	"batch size must be at least 1":          "اندازه دسته باید دست‌کم ۱ باشد",
+	"invalid --since: %w":                    "مقدار --since نامعتبر است: %w",
+	"invalid --until: %w":                    "مقدار --until نامعتبر است: %w",
+	"error reading history: %w":              "خطا در خواندن تاریخچه: %w",
+	"✅ No pending messages to sync\n":        "✅ پیام در انتظاری برای همگام‌سازی نیست\n",
+	"⚠️  Pack %s: %v\n":                      "⚠️  بسته %s: %v\n",
+	"error saving history: %w":               "خطا در ذخیره تاریخچه: %w",
+	"🔄 Synced %d/%d\n":                       "🔄 همگام‌سازی %d/%d\n",
+	"✅ %d record(s) synced, %d failed\n":     "✅ %d رکورد همگام شد، %d ناموفق\n",
+	"Show delivery rates from local history": "نمایش نرخ تحویل از تاریخچه محلی",
+	"Show delivery rates grouped by campaign (tag), recipient or line, using the\ndelivery states stored by \"smsir history sync\".": "نرخ تحویل را به تفکیک کمپین (برچسب)، گیرنده یا خط نشان می‌دهد؛\nبا استفاده از وضعیت‌هایی که \"smsir history sync\" ذخیره کرده است.",
+	"unknown grouping: %s (use campaign, recipient or line)":                                                                         "گروه‌بندی ناشناخته: %s (campaign، recipient یا line)",
+	"SENT":                "ارسالی",
+	"DELIVERED":           "تحویل‌شده",
+	"FAILED":              "ناموفق",
+	"PENDING":             "در انتظار",
+	"RATE":                "نرخ",
+	"📭 No messages found": "📭 پیامی پیدا نشد",
+	"Only sync messages sent after this date or age":                    "فقط پیام‌های ارسال‌شده پس از این تاریخ یا مدت همگام شوند",
+	"Number of records reconciled before each save":                     "تعداد رکوردهایی که پیش از هر ذخیره بررسی می‌شوند",
+	"Group by campaign (tag), recipient or line":                        "گروه‌بندی بر اساس کمپین (برچسب)، گیرنده یا خط",
+	"Only messages after this date (2006-01-02) or age (e.g. 24h, 7d)":  "فقط پیام‌های پس از این تاریخ (2006-01-02) یا مدت (مثلاً 24h، 7d)",
+	"Only messages before this date (2006-01-02) or age (e.g. 24h, 7d)": "فقط پیام‌های پیش از این تاریخ (2006-01-02) یا مدت (مثلاً 24h، 7d)",
+	"Only messages sent to this mobile number":                          "فقط پیام‌های ارسال‌شده به این شماره موبایل",
+	"Only messages containing this text":                                "فقط پیام‌هایی که این متن را دارند",
+	"Only messages with this tag":                                       "فقط پیام‌های دارای این برچسب",
+	"Maximum number of messages to include (0 for all)":                 "بیشترین تعداد پیام‌ها (۰ برای همه)",
+	"🆔 ID: %d\n":              "🆔 شناسه: %d\n",
+	"🕒 Time: %s\n":            "🕒 زمان: %s\n",
+	"👤 Profile: %s\n":         "👤 پروفایل: %s\n",
+	"🧭 Origin: %s\n":          "🧭 مبدأ: %s\n",
+	"📞 Line Number: %d\n":     "📞 شماره خط: %d\n",
+	"📦 Pack ID: %s\n":         "📦 شناسه بسته: %s\n",
+	"💰 Cost: %.2f SMS\n":      "💰 هزینه: %.2f پیامک\n",
+	"🏷️  Tags: %s\n":          "🏷️  برچسب‌ها: %s\n",
+	"📱 Recipients (%d): %s\n": "📱 گیرندگان (%d): %s\n",
+	"🔢 Message IDs: %v\n":     "🔢 شناسه‌های پیام: %v\n",
+	"📬 Delivery (synced %s): %d delivered, %d failed, %d pending\n": "📬 تحویل (همگام‌شده در %s): %d تحویل‌شده، %d ناموفق، %d در انتظار\n",
+	"💬 Message:\n%s\n": "💬 پیام:\n%s\n",
+	"expected a date like 2006-01-02 or an age like 24h or 7d": "تاریخی مانند 2006-01-02 یا مدتی مانند 24h یا 7d لازم است",
+
+	// lines
+	"Show available lines":                         "نمایش خطوط موجود",
+	"Show list of available lines for sending SMS": "نمایش فهرست خطوط موجود برای ارسال پیامک",
+	"error getting lines: %w":                      "خطا در دریافت خطوط: %w",
+	"⚠️  Line alias %q uses %s, which is not one of the account's lines\n": "⚠️  نام خط %q از %s استفاده می‌کند که جزو خطوط حساب نیست\n",
+	"LINE NUMBER":        "شماره خط",
+	"ALIASES":            "نام‌ها",
+	"📞 No lines found":   "📞 خطی پیدا نشد",
+	"📞 Available Lines:": "📞 خطوط موجود:",
+	"Manage named lines": "مدیریت خطوط نام‌دار",
+	"Give lines names such as otp, marketing or support. A name can be used\nwherever a line number is expected, e.g. 'smsir send -l marketing', and carries\nper-line defaults: a signature appended to every message, a per-part tariff\nfor cost estimates and the hours the line may send in.": "به خطوط نام‌هایی مانند otp، marketing یا support بدهید. هر جا شماره خط لازم است\nمی‌توان از نام استفاده کرد، مثلاً 'smsir send -l marketing'. هر نام پیش‌فرض‌های خود را دارد:\nامضایی که به هر پیام افزوده می‌شود، تعرفه هر بخش برای تخمین هزینه و ساعت‌های مجاز ارسال.",
+	"Create or update a named line": "ساخت یا به‌روزرسانی خط نام‌دار",
+	"Create or update a named line in the active profile.\n\nExamples:\n  smsir lines alias set otp 3000123\n  smsir lines alias set marketing 30001234 --signature \"لغو۱۱\" --tariff 1.2 --hours 08:00-21:00\n  smsir lines alias set mci 30005555 --operator mci --weight 3": "یک خط نام‌دار را در پروفایل فعال می‌سازد یا به‌روز می‌کند.\n\nمثال‌ها:\n  smsir lines alias set otp 3000123\n  smsir lines alias set marketing 30001234 --signature \"لغو۱۱\" --tariff 1.2 --hours 08:00-21:00\n  smsir lines alias set mci 30005555 --operator mci --weight 3",
+	"invalid line: %w":                            "خط نامعتبر: %w",
+	"✅ Line %q saved (profile %q)\n":              "✅ خط %q ذخیره شد (پروفایل %q)\n",
+	"Remove a named line":                         "حذف خط نام‌دار",
+	"Remove a named line from the active profile": "حذف یک خط نام‌دار از پروفایل فعال",
+	"line %q not found":                           "خط %q پیدا نشد",
+	"line %q is the profile's default line; change line_number first":    "خط %q خط پیش‌فرض پروفایل است؛ ابتدا line_number را تغییر دهید",
+	"✅ Line %q removed (profile %q)\n":                                   "✅ خط %q حذف شد (پروفایل %q)\n",
+	"List named lines":                                                   "فهرست خطوط نام‌دار",
+	"List the named lines of the active profile without calling the API": "فهرست خطوط نام‌دار پروفایل فعال، بدون فراخوانی API",
+	"NAME":       "نام",
+	"NUMBER":     "شماره",
+	"SIGNATURE":  "امضا",
+	"TARIFF":     "تعرفه",
+	"SEND HOURS": "ساعت ارسال",
+	"OPERATOR":   "اپراتور",
+	"WEIGHT":     "وزن",
+	"📞 No named lines; add one with 'smsir lines alias set <name> <number>'": "📞 خط نام‌داری نیست؛ با 'smsir lines alias set <name> <number>' یکی اضافه کنید",
+	"📞 Named Lines:": "📞 خطوط نام‌دار:",
+	"  💰 %s/part":    "  💰 %s برای هر بخش",
+	"text appended to every message sent from the line":                                  "متنی که به هر پیام ارسالی از این خط افزوده می‌شود",
+	"cost per message part, used for cost estimates":                                     "هزینه هر بخش پیام، برای تخمین هزینه",
+	"hours the line may send in, e.g. 08:00-21:00":                                       "ساعت‌های مجاز ارسال خط، مثلاً 08:00-21:00",
+	"operator the line serves with 'send --strategy operator': mci, irancell or rightel": "اپراتوری که خط در 'send --strategy operator' به آن ارسال می‌کند: mci، irancell یا rightel",
+	"share of recipients with 'send --strategy weighted' (default 1)":                    "سهم گیرندگان در 'send --strategy weighted' (پیش‌فرض ۱)",
+
+	// optout
+	"Opt-out (blocklist) management":                                              "مدیریت لغو عضویت (فهرست مسدود)",
+	"Manage the local blocklist of numbers that asked to stop receiving messages": "مدیریت فهرست مسدود محلی شماره‌هایی که خواسته‌اند دیگر پیام دریافت نکنند",
+	"Process opt-out replies from received messages":                              "پردازش پاسخ‌های لغو از پیام‌های دریافتی",
+	"Read received messages since the last run and add senders whose reply matches\nan opt-out keyword (e.g. \"لغو\" or \"STOP\") to the local blocklist.\n\nThe position of the last processed message is saved, so the command can run from cron.\nIf optout.confirm_message is configured, a confirmation SMS is sent to every newly\nblocked number from the line it replied to. Confirmations that fail to send are\nkept and retried by the next run.": "پیام‌های دریافتی از آخرین اجرا را می‌خواند و فرستندگانی را که پاسخشان با یک کلیدواژه لغو\n(مثلاً \"لغو\" یا \"STOP\") می‌خواند به فهرست مسدود محلی اضافه می‌کند.\n\nجای آخرین پیام پردازش‌شده ذخیره می‌شود، پس فرمان را می‌توان از cron اجرا کرد.\nاگر optout.confirm_message تنظیم شده باشد، به هر شماره تازه مسدودشده از همان خطی که\nبه آن پاسخ داده پیامک تأیید ارسال می‌شود. تأییدهایی که ارسالشان ناموفق باشد نگه داشته\nمی‌شوند و در اجرای بعدی دوباره ارسال می‌شوند.",
+	"error loading state: %w":                                           "خطا در بارگذاری وضعیت: %w",
+	"error loading blocklist: %w":                                       "خطا در بارگذاری فهرست مسدود: %w",
+	"error getting received messages: %w":                               "خطا در دریافت پیام‌های دریافتی: %w",
+	"⚠️  Skipping %s: %v\n":                                             "⚠️  %s نادیده گرفته شد: %v\n",
+	"⛔ %s opted out (%q)\n":                                             "⛔ %s لغو عضویت کرد (%q)\n",
+	"🔍 Dry run: %d message(s) scanned, %d number(s) would be blocked\n": "🔍 اجرای آزمایشی: %d پیام بررسی شد، %d شماره مسدود می‌شد\n",
+	"error saving blocklist: %w":                                        "خطا در ذخیره فهرست مسدود: %w",
+	"error saving state: %w":                                            "خطا در ذخیره وضعیت: %w",
+	"error sending confirmation SMS: %w":                                "خطا در ارسال پیامک تأیید: %w",
+	"⚠️  Could not save to history: %v":                                 "⚠️  ذخیره در تاریخچه ممکن نشد: %v",
+	"⚠️  Reply sent, but it could not be saved to history: %v":          "⚠️  پاسخ ارسال شد، اما ذخیره آن در تاریخچه ممکن نشد: %v",
+	"⚠️  Could not save to history: %v\n":                               "⚠️  ذخیره در تاریخچه ممکن نشد: %v\n",
+	"📤 Confirmation sent to %d number(s) from line %d\n":                "📤 پیام تأیید به %d شماره از خط %d ارسال شد\n",
+	"✅ %d message(s) scanned, %d number(s) added to blocklist\n":        "✅ %d پیام بررسی شد، %d شماره به فهرست مسدود اضافه شد\n",
+	"Show blocklisted numbers":                                          "نمایش شماره‌های مسدود",
+	"Show all numbers on the local blocklist":                           "نمایش همه شماره‌های فهرست مسدود محلی",
+	"MOBILE":                       "موبایل",
+	"ADDED":                        "افزوده‌شده",
+	"REASON":                       "دلیل",
+	"⛔ Blocklist is empty":         "⛔ فهرست مسدود خالی است",
+	"⛔ Blocklisted Numbers:":       "⛔ شماره‌های مسدود:",
+	"Add numbers to the blocklist": "افزودن شماره به فهرست مسدود",
+	"Add one or more mobile numbers to the local blocklist":      "افزودن یک یا چند شماره موبایل به فهرست مسدود محلی",
+	"✅ %d number(s) added to blocklist\n":                        "✅ %d شماره به فهرست مسدود اضافه شد\n",
+	"Remove numbers from the blocklist":                          "حذف شماره از فهرست مسدود",
+	"Remove one or more mobile numbers from the local blocklist": "حذف یک یا چند شماره موبایل از فهرست مسدود محلی",
+	"✅ %d number(s) removed from blocklist\n":                    "✅ %d شماره از فهرست مسدود حذف شد\n",
+	"How far back to look on the first run":                      "در اجرای اول تا چه مدت قبل بررسی شود",
+	"Number of messages fetched per request":                     "تعداد پیام‌های دریافتی در هر درخواست",
+	"Show matches without changing the blocklist":                "نمایش موارد منطبق بدون تغییر فهرست مسدود",
+	"Do not send the confirmation SMS":                           "پیامک تأیید ارسال نشود",
+	"Why the number is blocked":                                  "دلیل مسدود شدن شماره",
+
+	// contacts
+	"Local contacts and groups": "مخاطبان و گروه‌های محلی",
+	"Manage the local address book used by the send wizard's contacts picker.\n\nContacts can belong to groups, so a whole group can be picked as recipients at once.": "مدیریت دفترچه نشانی محلی که انتخابگر مخاطبان در راهنمای ارسال از آن استفاده می‌کند.\n\nمخاطبان می‌توانند عضو گروه باشند تا بتوان یک گروه را یک‌جا به‌عنوان گیرنده انتخاب کرد.",
+	"Show contacts": "نمایش مخاطبان",
+	"Show all contacts, or only the members of one group": "نمایش همه مخاطبان یا فقط اعضای یک گروه",
+	"error loading contacts: %w":                          "خطا در بارگذاری مخاطبان: %w",
+	"GROUPS":                                              "گروه‌ها",
+	"👤 No contacts found":                                 "👤 مخاطبی پیدا نشد",
+	"👤 Contacts:":                                         "👤 مخاطبان:",
+	"Add or update a contact":                             "افزودن یا به‌روزرسانی مخاطب",
+	"Add a contact, or update the name and groups of an existing number.\n\nExamples:\n  smsir contacts add \"Sara Ahmadi\" 09121234567\n  smsir contacts add Ali 09351234567 --group customers --group vip": "افزودن مخاطب یا به‌روزرسانی نام و گروه‌های یک شماره موجود.\n\nمثال‌ها:\n  smsir contacts add \"Sara Ahmadi\" 09121234567\n  smsir contacts add Ali 09351234567 --group customers --group vip",
+	"error saving contacts: %w":                "خطا در ذخیره مخاطبان: %w",
+	"✅ Contact %s (%s) saved\n":                "✅ مخاطب %s (%s) ذخیره شد\n",
+	"Remove contacts":                          "حذف مخاطبان",
+	"Remove contacts by name or mobile number": "حذف مخاطبان با نام یا شماره موبایل",
+	"✅ %d contact(s) removed\n":                "✅ %d مخاطب حذف شد\n",
+	"Only show members of this group":          "فقط نمایش اعضای این گروه",
+	"Group to put the contact in (repeatable)": "گروهی که مخاطب در آن قرار می‌گیرد (تکرارپذیر)",
+
+	// profile
+	"Profile (account) management": "مدیریت پروفایل (حساب)",
+	"Manage named profiles, e.g. separate SMS.ir accounts for marketing, OTP and staging.\n\nCommands use the default profile unless another is chosen with --profile or SMSIR_PROFILE.": "مدیریت پروفایل‌های نام‌دار، مثلاً حساب‌های جدای SMS.ir برای بازاریابی، کد یک‌بارمصرف و آزمایش.\n\nفرمان‌ها از پروفایل پیش‌فرض استفاده می‌کنند، مگر پروفایل دیگری با --profile یا SMSIR_PROFILE انتخاب شود.",
+	"List profiles": "فهرست پروفایل‌ها",
+	"List all profiles and show which one is the default and which one is active": "فهرست همه پروفایل‌ها، با نمایش پروفایل پیش‌فرض و فعال",
+	"BASE URL":             "نشانی پایه",
+	"DEFAULT":              "پیش‌فرض",
+	"ACTIVE":               "فعال",
+	"👤 Profiles:":          "👤 پروفایل‌ها:",
+	"  %s %s%s  line %s\n": "  %s %s%s  خط %s\n",
+	"Add a profile":        "افزودن پروفایل",
+	"Add a new profile with its own API Key and line number": "افزودن پروفایل تازه با کلید API و شماره خط خودش",
+	"✅ Profile %q added\n":                                   "✅ پروفایل %q اضافه شد\n",
+	"Set the default profile":                                "تعیین پروفایل پیش‌فرض",
+	"Make a profile the default for all commands":            "یک پروفایل را برای همه فرمان‌ها پیش‌فرض می‌کند",
+	"✅ Default profile is now %q\n":                          "✅ پروفایل پیش‌فرض اکنون %q است\n",
+	"Remove a profile":                                       "حذف پروفایل",
+	"Remove a profile and its settings":                      "حذف یک پروفایل و تنظیماتش",
+	"✅ Profile %q removed\n":                                 "✅ پروفایل %q حذف شد\n",
+	"Rename a profile":                                       "تغییر نام پروفایل",
+	"Rename a profile, keeping it the default if it was":     "تغییر نام پروفایل؛ اگر پیش‌فرض بوده، پیش‌فرض می‌ماند",
+	"✅ Profile %q renamed to %q\n":                           "✅ نام پروفایل %q به %q تغییر کرد\n",
+	"API base URL (optional)":                                "نشانی پایه API (اختیاری)",
+
+	// menu
+	"Launch interactive menu": "اجرای منوی تعاملی",
+	"Launch interactive menu with beautiful user interface to choose between different modes: dashboard with real-time updates, configuration setup, and command line operations. This provides an easy-to-use graphical interface for navigating all SMS.ir CLI features.": "منوی تعاملی را با رابطی زیبا برای انتخاب بین حالت‌های مختلف اجرا می‌کند: داشبورد با به‌روزرسانی زنده، تنظیمات و کار با خط فرمان. این رابط ساده‌ای برای دسترسی به همه امکانات خط فرمان SMS.ir است.",
+
+	// send
+	"Send SMS message": "ارسال پیامک",
+	"Send SMS message to one or more mobile numbers":            "ارسال پیامک به یک یا چند شماره موبایل",
+	"error getting message flag: %w":                            "خطا در خواندن گزینه message: %w",
+	"message is required":                                       "متن پیام لازم است",
+	"error getting to flag: %w":                                 "خطا در خواندن گزینه to: %w",
+	"to mobiles is required":                                    "شماره‌های گیرنده لازم است",
+	"⛔ Skipping %d blocklisted number(s): %s\n":                 "⛔ %d شماره مسدود نادیده گرفته شد: %s\n",
+	"all recipients are blocklisted":                            "همه گیرندگان در فهرست مسدود هستند",
+	"error getting line flag: %w":                               "خطا در خواندن گزینه line: %w",
+	"line number is required (use --line flag or configure it)": "شماره خط لازم است (از --line استفاده کنید یا آن را تنظیم کنید)",
+	"PACK ID":                    "شناسه بسته",
+	"MESSAGE ID":                 "شناسه پیام",
+	"✅ SMS sent successfully!\n": "✅ پیامک با موفقیت ارسال شد!\n",
+	"↪️  Sent by failover profile %q from line %s\n":                     "↪️  با پروفایل جایگزین %q از خط %s ارسال شد\n",
+	"📱 Message IDs: %v\n":                                                "📱 شناسه‌های پیام: %v\n",
+	"📊 Total messages: %d\n":                                             "📊 تعداد کل پیام‌ها: %d\n",
+	"💡 Estimated cost from %s: %s (%d part(s) × %d recipient(s) × %s)\n": "💡 هزینه تخمینی از %s: %s (%d بخش × %d گیرنده × %s)\n",
+	"%w (use --ignore-hours to send anyway)":                             "%w (برای ارسال در هر صورت از --ignore-hours استفاده کنید)",
+	"error sending SMS: %w":                                              "خطا در ارسال پیامک: %w",
+	"LINE":                                                               "خط",
+	"PROFILE":                                                            "پروفایل",
+	"RECIPIENTS":                                                         "گیرندگان",
+	"COST":                                                               "هزینه",
+	"ERROR":                                                              "خطا",
+	"📤 Sent from %d line(s) (%s):\n":                                     "📤 ارسال از %d خط (%s):\n",
+	" via failover profile %q":                                           " با پروفایل جایگزین %q",
+	"  ❌ %s: %d recipient(s), %s\n":                                      "  ❌ %s: %d گیرنده، %s\n",
+	"  ✅ %s: %d recipient(s), pack %s, cost %.2f SMS\n":                                                                        "  ✅ %s: %d گیرنده، بسته %s، هزینه %.2f پیامک\n",
+	"%d of %d line(s) failed":                                                                                                  "%d خط از %d خط ناموفق بود",
+	"Message text to send":                                                                                                     "متن پیام",
+	"Comma-separated list of mobile numbers":                                                                                   "فهرست شماره‌های موبایل، جداشده با ویرگول",
+	"Line number or line alias (optional, uses config if not provided)":                                                        "شماره یا نام خط (اختیاری؛ در غیر این صورت از تنظیمات)",
+	"Do not append the line's signature":                                                                                       "امضای خط افزوده نشود",
+	"Send even outside the line's allowed send hours":                                                                          "ارسال حتی خارج از ساعت‌های مجاز خط",
+	"Tag stored with the message in history (repeatable)":                                                                      "برچسبی که با پیام در تاریخچه ذخیره می‌شود (تکرارپذیر)",
+	"Schedule the message for this time (2006-01-02 15:04) or after this delay (e.g. 30m, 2h)":                                 "زمان‌بندی پیام برای این زمان (2006-01-02 15:04) یا پس از این مدت (مثلاً 30m، 2h)",
+	"invalid --at %q: expected a time like 2006-01-02 15:04 or a delay like 2h":                                                "مقدار --at %q نامعتبر است: زمانی مانند 2006-01-02 15:04 یا مدتی مانند 2h لازم است",
+	"--at %s is not in the future":                                                                                             "زمان --at %s در آینده نیست",
+	"🕒 Scheduled for %s\n":                                                                                                     "🕒 زمان‌بندی‌شده برای %s\n",
+	"Spread the recipients over these lines or aliases, e.g. otp,marketing:3":                                                  "پخش گیرندگان میان این خطوط یا نام‌ها، مثلاً otp,marketing:3",
+	"How --lines splits the recipients: round-robin, weighted or operator":                                                     "روش تقسیم گیرندگان در --lines: round-robin، weighted یا operator",
+	"On credit or API key errors, send from the profiles in failover.profiles (default from failover.commands)":                "در خطای اعتبار یا کلید API، از پروفایل‌های failover.profiles ارسال شود (پیش‌فرض از failover.commands)",
+	"failover is on but profile %q has no failover profiles; set them with 'smsir config set failover.profiles <profile>,...'": "ارسال جایگزین فعال است اما پروفایل %q پروفایل جایگزینی ندارد؛ با 'smsir config set failover.profiles <profile>,...' تنظیمشان کنید",
+	"⚠️  Skipping failover profile %q: %v\n":                                                                                   "⚠️  پروفایل جایگزین %q نادیده گرفته شد: %v\n",
+	"↪️  Profile %q could not send (%v); failing over to profile %q\n":                                                         "↪️  پروفایل %q نتوانست ارسال کند (%v)؛ ارسال با پروفایل %q\n",
+	"profile %q: %w": "پروفایل %q: %w",
+	"no profile could send, last error from profile %q: %w": "هیچ پروفایلی نتوانست ارسال کند، آخرین خطا از پروفایل %q: %w",
+	"no API key configured":                                 "کلید API تنظیم نشده است",
+
+	// watch
+	"Watch delivery progress of a sent pack": "پیگیری تحویل یک بسته ارسالی",
+	"Poll the delivery report of a pack until every message is delivered or failed.\n\nThe poll interval starts short and grows while nothing changes. The command exits\nwith a non-zero code when the timeout passes or when the failure rate is above\n--max-failure-rate, which is off by default. Progress is shown in an interactive\nview on a terminal and as plain lines on stderr otherwise (or with --plain).": "گزارش تحویل یک بسته را تا تحویل یا شکست همه پیام‌ها دنبال می‌کند.\n\nفاصله بررسی‌ها کوتاه شروع می‌شود و تا وقتی چیزی تغییر نکند بیشتر می‌شود. اگر مهلت بگذرد\nیا نرخ شکست از --max-failure-rate (که به طور پیش‌فرض خاموش است) بیشتر شود، فرمان با کد غیرصفر خارج می‌شود. پیشرفت در ترمینال\nدر نمای تعاملی و در غیر این صورت (یا با --plain) به صورت خط‌های ساده در stderr نمایش داده می‌شود.",
+	"TOTAL": "کل",
+	"📊 Delivered: %d, Failed: %d, Pending: %d, Total: %d\n": "📊 تحویل‌شده: %d، ناموفق: %d، در انتظار: %d، کل: %d\n",
+	"failure rate %.1f%% exceeds threshold %.1f%%":          "نرخ شکست %.1f%% از آستانه %.1f%% بیشتر است",
+	"error getting pack report: %w":                         "خطا در دریافت گزارش بسته: %w",
+	"timed out after %s with %d message(s) pending":         "پس از %s مهلت تمام شد و %d پیام در انتظار است",
+	"unexpected watch result":                               "نتیجه پیگیری غیرمنتظره بود",
+	"watch cancelled with %d message(s) pending":            "پیگیری لغو شد و %d پیام در انتظار است",
+	"Give up after this long":                               "پس از این مدت رها شود",
+	"Shortest time between polls":                           "کمترین فاصله بین بررسی‌ها",
+	"Longest time between polls":                            "بیشترین فاصله بین بررسی‌ها",
+	"Exit with an error if more than this fraction of messages fail (0-1; the default 1 never fails)": "اگر سهم پیام‌های ناموفق بیشتر از این مقدار باشد با خطا خارج شود (۰ تا ۱؛ پیش‌فرض ۱ هرگز خطا نمی‌دهد)",
+	"Print progress lines to stderr instead of the interactive view":                                  "به جای نمای تعاملی، خط‌های پیشرفت در stderr چاپ شود",
+
+	// API
+	"Failed":              "ناموفق",
+	"Success":             "موفق",
+	"invalid API key":     "کلید API نامعتبر است",
+	"API key is disabled": "کلید API غیرفعال است",
+	"API key is not allowed from this IP address": "کلید API از این نشانی IP مجاز نیست",
+	"account is inactive":                         "حساب غیرفعال است",
+	"account is suspended":                        "حساب مسدود شده است",
+	"too many requests":                           "تعداد درخواست‌ها بیش از حد است",
+	"invalid line number":                         "شماره خط نامعتبر است",
+	"insufficient credit":                         "اعتبار کافی نیست",
+	"Unknown status: %d":                          "وضعیت ناشناخته: %d",
+	"logical error: invalid request":              "خطای منطقی: درخواست نامعتبر",
+	"authentication error: invalid API key":       "خطای احراز هویت: کلید API نامعتبر است",
+	"rate limit exceeded: please wait a moment":   "از سقف درخواست‌ها گذشتید: لطفاً کمی صبر کنید",
+	"server error: unexpected error":              "خطای سرور: خطای غیرمنتظره",
+	"unknown error with status code: %d":          "خطای ناشناخته با کد وضعیت %d",
+	"failed to marshal request body: %w":          "ساخت بدنه درخواست ناموفق بود: %w",
+	"failed to create request: %w":                "ساخت درخواست ناموفق بود: %w",
+	"failed to perform request: %w":               "انجام درخواست ناموفق بود: %w",
+	"failed to read response body: %w":            "خواندن پاسخ ناموفق بود: %w",
+	"failed to unmarshal response: %w":            "خواندن پاسخ ناموفق بود: %w",
+	"Pending":                                     "در انتظار",
+	"Delivered":                                   "تحویل‌شده",
+	"Undelivered":                                 "تحویل‌نشده",
+	"In operator":                                 "در اپراتور",
+	"Not sent to operator":                        "به اپراتور ارسال نشد",
+	"At operator":                                 "نزد اپراتور",
+	"Blacklisted":                                 "در فهرست سیاه",
+
+	// Online configuration check
+	"configuration":                         "پیکربندی",
+	"valid":                                 "معتبر",
+	"base url":                              "نشانی پایه",
+	"clock":                                 "ساعت",
+	"line number":                           "شماره خط",
+	"skipped":                               "انجام نشد",
+	"%s is not reachable: %v":               "%s در دسترس نیست: %v",
+	"%s answered in %s":                     "%s در %s پاسخ داد",
+	"server did not report its time":        "سرور زمان خود را اعلام نکرد",
+	"local clock is %s ahead of the server": "ساعت سیستم %s از سرور جلوتر است",
+	"local clock is %s behind the server":   "ساعت سیستم %s از سرور عقب‌تر است",
+	"within %s of the server":               "حداکثر %s اختلاف با سرور",
+	"accepted (credit %s)":                  "پذیرفته شد (اعتبار %s)",
+	"could not list lines: %v":              "دریافت فهرست خطوط ممکن نشد: %v",
+	"%s belongs to the account":             "%s متعلق به حساب است",
+	"not set (available: %s)":               "تنظیم نشده (موجود: %s)",
+	"%s is not one of the account's lines (available: %s)": "%s جزو خطوط حساب نیست (موجود: %s)",
+
+	// Configuration
+	"profile %q not found":                                                        "پروفایل %q پیدا نشد",
+	"failed to read API key (%s): %w":                                             "خواندن کلید API ناموفق بود (%s): %w",
+	"failed to resolve config path: %w":                                           "تعیین مسیر تنظیمات ناموفق بود: %w",
+	"failed to create config directory: %w":                                       "ساخت پوشه تنظیمات ناموفق بود: %w",
+	"failed to get user home directory: %w":                                       "یافتن پوشه خانگی کاربر ناموفق بود: %w",
+	"api key is required":                                                         "کلید API لازم است",
+	"line number is required":                                                     "شماره خط لازم است",
+	"base_url must be an http or https URL":                                       "base_url باید نشانی http یا https باشد",
+	"dashboard.refresh must not be negative":                                      "dashboard.refresh نباید منفی باشد",
+	"dashboard.low_credit must not be negative":                                   "dashboard.low_credit نباید منفی باشد",
+	"dashboard.recent must be at least 1":                                         "dashboard.recent باید دست‌کم ۱ باشد",
+	"failed to unmarshal credit sample: %w":                                       "خواندن نمونه اعتبار ناموفق بود: %w",
+	"failed to marshal credit sample: %w":                                         "ساخت نمونه اعتبار ناموفق بود: %w",
+	"line_number: %w":                                                             "line_number: %w",
+	"failed to marshal config: %w":                                                "ساخت تنظیمات ناموفق بود: %w",
+	"failed to write config file: %w":                                             "نوشتن فایل تنظیمات ناموفق بود: %w",
+	"failed to set config file permissions: %w":                                   "تنظیم دسترسی فایل تنظیمات ناموفق بود: %w",
+	"unknown config key %q (see 'smsir config list')":                             "کلید تنظیمات ناشناخته %q (به 'smsir config list' نگاه کنید)",
+	"%s must be JSON: %w":                                                         "%s باید JSON باشد: %w",
+	"failed to encode %s: %w":                                                     "رمزگذاری %s ناموفق بود: %w",
+	"config key %q has an unsupported type":                                       "نوع کلید تنظیمات %q پشتیبانی نمی‌شود",
+	"%s must be a whole number":                                                   "%s باید عدد صحیح باشد",
+	"%s must be true or false":                                                    "%s باید true یا false باشد",
+	"unknown line %q (not a number or a configured alias)":                        "خط ناشناخته %q (نه شماره است و نه نام تنظیم‌شده)",
+	"invalid line number %q":                                                      "شماره خط نامعتبر %q",
+	"line %s may only send between %s":                                            "خط %s فقط در بازه %s می‌تواند ارسال کند",
+	"send hours %q must look like 08:00-21:00":                                    "ساعت ارسال %q باید مانند 08:00-21:00 باشد",
+	"line alias %q must not be a number":                                          "نام خط %q نباید عدد باشد",
+	"line %q: number %q is not a line number":                                     "خط %q: %q شماره خط نیست",
+	"line %q: tariff must not be negative":                                        "خط %q: تعرفه نباید منفی باشد",
+	"line %q: %w":                                                                 "خط %q: %w",
+	"line %q: weight must not be negative":                                        "خط %q: وزن نباید منفی باشد",
+	"line %q: unknown operator %q (use one of %s)":                                "خط %q: اپراتور ناشناخته %q (یکی از %s)",
+	"failed to create default config: %w":                                         "ساخت تنظیمات پیش‌فرض ناموفق بود: %w",
+	"failed to read config file: %w":                                              "خواندن فایل تنظیمات ناموفق بود: %w",
+	"failed to unmarshal config: %w":                                              "خواندن تنظیمات ناموفق بود: %w",
+	"failed to unmarshal profile %q: %w":                                          "خواندن پروفایل %q ناموفق بود: %w",
+	"profile name is required":                                                    "نام پروفایل لازم است",
+	"profile %q already exists":                                                   "پروفایل %q از قبل وجود دارد",
+	"profile %q is the default; choose another default first":                     "پروفایل %q پیش‌فرض است؛ ابتدا پروفایل پیش‌فرض دیگری انتخاب کنید",
+	"failed to read API key of profile %q (%s): %w":                               "خواندن کلید API پروفایل %q ناموفق بود (%s): %w",
+	"failed to read API key of profile %q: %w":                                    "خواندن کلید API پروفایل %q ناموفق بود: %w",
+	"failed to read project config %s: %w":                                        "خواندن تنظیمات پروژه %s ناموفق بود: %w",
+	"failed to parse project config %s: %w":                                       "تجزیه تنظیمات پروژه %s ناموفق بود: %w",
+	"project config %s cannot choose the profile; use --profile or SMSIR_PROFILE": "تنظیمات پروژه %s نمی‌تواند پروفایل را انتخاب کند؛ از --profile یا SMSIR_PROFILE استفاده کنید",
+	"project file":                                                                "فایل پروژه",
+	"project config %s cannot set base_url; set it in the user configuration":     "تنظیمات پروژه %s نمی‌تواند base_url را تعیین کند؛ آن را در تنظیمات کاربر تنظیم کنید",
+	"project config %s cannot set the signature of line %q; set it with 'smsir lines alias set'": "تنظیمات پروژه %s نمی‌تواند امضای خط %q را تعیین کند؛ آن را با 'smsir lines alias set' تنظیم کنید",
+	"failed to store API key in %s: %w":                    "ذخیره کلید API در %s ناموفق بود: %w",
+	"unknown secret backend %q (use keyring, file or env)": "محل نگهداری کلید ناشناخته %q (keyring، file یا env)",
+	"set SMSIR_PASSPHRASE to unlock the secrets file":      "برای باز کردن فایل کلیدها SMSIR_PASSPHRASE را تنظیم کنید",
+	"failed to read passphrase: %w":                        "خواندن عبارت عبور ناموفق بود: %w",
+	"🔑 Passphrase for the SMS.ir secrets file: ":           "🔑 عبارت عبور فایل کلیدهای SMS.ir: ",
+	"🔑 Repeat the passphrase for the new secrets file: ":   "🔑 عبارت عبور فایل کلیدهای جدید را تکرار کنید: ",
+	"the passphrases do not match":                         "عبارت‌های عبور یکسان نیستند",
+	"the env backend is read-only; set %s":                 "محل نگهداری env فقط‌خواندنی است؛ %s را تنظیم کنید",
+
+	// Local files
+	"failed to read blocklist: %w":                                "خواندن فهرست مسدود ناموفق بود: %w",
+	"failed to unmarshal blocklist: %w":                           "خواندن فهرست مسدود ناموفق بود: %w",
+	"failed to marshal blocklist: %w":                             "ساخت فهرست مسدود ناموفق بود: %w",
+	"failed to write blocklist: %w":                               "نوشتن فهرست مسدود ناموفق بود: %w",
+	"failed to read contacts: %w":                                 "خواندن مخاطبان ناموفق بود: %w",
+	"failed to unmarshal contacts: %w":                            "خواندن مخاطبان ناموفق بود: %w",
+	"failed to marshal contacts: %w":                              "ساخت فهرست مخاطبان ناموفق بود: %w",
+	"failed to write contacts: %w":                                "نوشتن مخاطبان ناموفق بود: %w",
+	"contact name is required":                                    "نام مخاطب الزامی است",
+	"failed to open history database: %w":                         "باز کردن پایگاه داده تاریخچه ناموفق بود: %w",
+	"failed to initialize history database: %w":                   "آماده‌سازی پایگاه داده تاریخچه ناموفق بود: %w",
+	"failed to allocate history id: %w":                           "گرفتن شناسه تاریخچه ناموفق بود: %w",
+	"failed to marshal history record: %w":                        "ساخت رکورد تاریخچه ناموفق بود: %w",
+	"history record %d not found":                                 "رکورد تاریخچه %d پیدا نشد",
+	"no history record for pack %s":                               "رکورد تاریخچه‌ای برای بسته %s نیست",
+	"failed to unmarshal history record: %w":                      "خواندن رکورد تاریخچه ناموفق بود: %w",
+	"failed to encode yaml: %w":                                   "ساخت yaml ناموفق بود: %w",
+	"failed to marshal output: %w":                                "ساخت خروجی ناموفق بود: %w",
+	"failed to unmarshal output: %w":                              "خواندن خروجی ناموفق بود: %w",
+	"unknown output format %q (use %s)":                           "قالب خروجی ناشناخته %q (%s)",
+	"invalid character %q in mobile number":                       "نویسه نامعتبر %q در شماره موبایل",
+	"invalid mobile number: %s":                                   "شماره موبایل نامعتبر: %s",
+	"unknown strategy %q":                                         "روش ناشناخته %q",
+	"unknown strategy %q (use round-robin, weighted or operator)": "روش ناشناخته %q (round-robin، weighted یا operator)",
+	"no line has an operator; set one with 'smsir lines alias set <name> <number> --operator mci|irancell|rightel'": "هیچ خطی اپراتور ندارد؛ با 'smsir lines alias set <name> <number> --operator mci|irancell|rightel' تنظیمش کنید",
+	"invalid weight %q for line %s":                     "وزن نامعتبر %q برای خط %s",
+	"line %s is listed more than once":                  "خط %s بیش از یک بار آمده است",
+	"at least one line is required":                     "دست‌کم یک خط لازم است",
+	"%w: environment variable %s is not set":            "%w: متغیر محیطی %s تنظیم نشده است",
+	"failed to read secrets file: %w":                   "خواندن فایل کلیدها ناموفق بود: %w",
+	"failed to unmarshal secrets file: %w":              "خواندن فایل کلیدها ناموفق بود: %w",
+	"failed to decrypt secrets file: wrong passphrase?": "رمزگشایی فایل کلیدها ناموفق بود: عبارت عبور اشتباه است؟",
+	"failed to unmarshal secrets: %w":                   "خواندن کلیدها ناموفق بود: %w",
+	"failed to marshal secrets: %w":                     "ساخت کلیدها ناموفق بود: %w",
+	"failed to generate salt: %w":                       "ساخت salt ناموفق بود: %w",
+	"failed to generate nonce: %w":                      "ساخت nonce ناموفق بود: %w",
+	"failed to marshal secrets file: %w":                "ساخت فایل کلیدها ناموفق بود: %w",
+	"failed to write secrets file: %w":                  "نوشتن فایل کلیدها ناموفق بود: %w",
+	"no passphrase available for the secrets file":      "عبارت عبوری برای فایل کلیدها در دسترس نیست",
+	"passphrase is required for the secrets file":       "فایل کلیدها عبارت عبور لازم دارد",
+	"failed to derive key: %w":                          "ساخت کلید ناموفق بود: %w",
+	"invalid secret reference %q":                       "ارجاع کلید نامعتبر %q",
+	"failed to read state file: %w":                     "خواندن فایل وضعیت ناموفق بود: %w",
+	"failed to unmarshal state file: %w":                "خواندن فایل وضعیت ناموفق بود: %w",
+	"failed to decode state %q: %w":                     "خواندن وضعیت %q ناموفق بود: %w",
+	"failed to encode state %q: %w":                     "ساخت وضعیت %q ناموفق بود: %w",
+	"failed to marshal state: %w":                       "ساخت وضعیت ناموفق بود: %w",
+	"failed to write state file: %w":                    "نوشتن فایل وضعیت ناموفق بود: %w",
+
+	// Interactive UI
+	"Goodbye! 👋\n": "خدانگهدار! 👋\n",
+	"A simple message can connect worlds with a single command": "یک پیام ساده با یک فرمان دنیاها را به هم وصل می‌کند",
+	"Loading...":                           "در حال بارگذاری...",
+	"Connecting to SMS.ir...":              "در حال اتصال به SMS.ir...",
+	"Preparing user interface...":          "در حال آماده‌سازی رابط کاربری...",
+	"Almost ready!":                        "تقریباً آماده است!",
+	"Use ↑/↓ or j/k to navigate":           "برای جابه‌جایی از ↑/↓ یا j/k استفاده کنید",
+	"Press Enter to select":                "برای انتخاب Enter را بزنید",
+	"Press q or Ctrl+C to quit":            "برای خروج q یا Ctrl+C را بزنید",
+	"🔧 Configure API Key & Line Number":    "🔧 تنظیم کلید API و شماره خط",
+	"📤 Send SMS":                           "📤 ارسال پیامک",
+	"📊 Dashboard":                          "📊 داشبورد",
+	"💻 Command Line Mode":                  "💻 حالت خط فرمان",
+	"✅ Configuration saved successfully":   "✅ تنظیمات با موفقیت ذخیره شد",
+	"Could not save the configuration: %v": "ذخیره تنظیمات ممکن نشد: %v",
+	"Could not load the configuration: %v": "بارگذاری تنظیمات ممکن نشد: %v",
+	"The configuration is incomplete: %v":  "تنظیمات کامل نیست: %v",
+	"c: open the configuration wizard":     "c: باز کردن تنظیمات",
+	"Esc: dismiss":                         "Esc: بستن",
+	"SMS.ir CLI - Command Line Mode":       "خط فرمان SMS.ir - حالت خط فرمان",
+	"For more information, run: %s --help": "برای اطلاعات بیشتر اجرا کنید: %s --help",
+	"Press any key to exit...":             "برای خروج یک کلید را بزنید...",
+
+	"Configuration cancelled.\n":            "تنظیمات لغو شد.\n",
+	"🔧 Configuration Setup":                 "🔧 تنظیمات",
+	"API Key":                               "کلید API",
+	"Line Number":                           "شماره خط",
+	"Confirm":                               "تأیید",
+	"Enter your SMS.ir API Key:":            "کلید API حساب SMS.ir را وارد کنید:",
+	"Type here or press Ctrl+V to paste...": "اینجا بنویسید یا برای چسباندن Ctrl+V را بزنید...",
+	"Choose your Line Number:":              "شماره خط را انتخاب کنید:",
+	"Enter your Line Number:":               "شماره خط را وارد کنید:",
+	"Confirm Configuration:":                "تأیید تنظیمات:",
+	"API Key: %s":                           "کلید API: %s",
+	"Line Number: %s":                       "شماره خط: %s",
+	"⏳ Checking the configuration against your account...": "⏳ در حال بررسی تنظیمات با حساب شما...",
+	"Type your information and press Enter to continue":    "اطلاعات را وارد کنید و برای ادامه Enter را بزنید",
+	"Press Ctrl+V to paste from clipboard":                 "برای چسباندن از کلیپ‌بورد Ctrl+V را بزنید",
+	"Press q or Ctrl+C to cancel":                          "برای لغو q یا Ctrl+C را بزنید",
+	"Press Ctrl+C to cancel":                               "برای لغو Ctrl+C را بزنید",
+	"Press Enter to check again":                           "برای بررسی دوباره Enter را بزنید",
+	"Press Enter to check and save configuration":          "برای بررسی و ذخیره تنظیمات Enter را بزنید",
+
+	"📱 SMS.ir CLI Dashboard": "📱 داشبورد خط فرمان SMS.ir",
+	"Loading... ⏳":           "در حال بارگذاری... ⏳",
+	"Error: %v":              "خطا: %v",
+	"💰 Current Credit":       "💰 اعتبار فعلی",
+	"%.2f SMS":               "%.2f پیامک",
+	"📞 Available Lines":      "📞 خطوط موجود",
+	"No lines found":         "خطی پیدا نشد",
+	"Commands:":              "فرمان‌ها:",
+	"r - Refresh data":       "r - به‌روزرسانی",
+	"q - Quit":               "q - خروج",
+
+	"Credit since %s": "اعتبار از %s",
+	"🔥 Burn rate: not enough credit history yet":                           "🔥 نرخ مصرف: هنوز سابقه اعتبار کافی نیست",
+	"🔥 Burn rate: no credit used in the last 7 days":                       "🔥 نرخ مصرف: در ۷ روز گذشته اعتباری مصرف نشده",
+	"🔥 Burn rate: %.1f SMS/day • ⏳ about %.0f day(s) left":                 "🔥 نرخ مصرف: %.1f پیامک در روز • ⏳ حدود %.0f روز باقی مانده",
+	"⚠️  Low credit: %.2f SMS left, below the warning threshold of %d SMS": "⚠️  اعتبار کم: %.2f پیامک باقی مانده، کمتر از آستانه هشدار %d پیامک",
+	"Auto-refresh in %s":                "به‌روزرسانی خودکار تا %s دیگر",
+	"Auto-refresh off":                  "به‌روزرسانی خودکار خاموش",
+	"tab/arrows - Choose panel":         "tab/جهت‌ها - انتخاب پنل",
+	"enter - Details":                   "enter - جزئیات",
+	"↑/↓ - Scroll":                      "↑/↓ - پیمایش",
+	"esc - Back to panels":              "esc - بازگشت به پنل‌ها",
+	"… %d more, enter for details":      "… %d مورد دیگر، enter برای جزئیات",
+	"  %d-%d of %d":                     "  %d-%d از %d",
+	"%s  %.2f SMS":                      "%s  %.2f پیامک",
+	"📤 Recent Sends":                    "📤 ارسال‌های اخیر",
+	"📅 Today":                           "📅 امروز",
+	"📥 Inbox":                           "📥 صندوق دریافت",
+	"🕒 Scheduled":                       "🕒 زمان‌بندی‌شده",
+	"History unavailable: %v":           "تاریخچه در دسترس نیست: %v",
+	"Inbox unavailable: %v":             "صندوق دریافت در دسترس نیست: %v",
+	"No sends yet":                      "هنوز ارسالی نشده",
+	"No messages received":              "پیامی دریافت نشده",
+	"Nothing scheduled":                 "ارسال زمان‌بندی‌شده‌ای نیست",
+	"no report yet":                     "هنوز گزارشی نیست",
+	"%.0f%% delivered":                  "%.0f%% تحویل‌شده",
+	"%s • %d • %d recipient(s) • %s":    "%s • %d • %d گیرنده • %s",
+	"%s • %d • %d recipient(s) • in %s": "%s • %d • %d گیرنده • %s دیگر",
+	"   pack %s • ✅ %d • ❌ %d • ⏳ %d • 💰 %.2f SMS": "   بسته %s • ✅ %d • ❌ %d • ⏳ %d • 💰 %.2f پیامک",
+	"   pack %s • 💰 %.2f SMS":                      "   بسته %s • 💰 %.2f پیامک",
+	"📤 Sent: %d":                                   "📤 ارسال‌شده: %d",
+	"✅ Delivered: %d":                              "✅ تحویل‌شده: %d",
+	"❌ Failed: %d":                                 "❌ ناموفق: %d",
+	"⏳ Pending: %d":                                "⏳ در انتظار: %d",
+	"✅ Reply sent":                                 "✅ پاسخ ارسال شد",
+	"❌ Reply not sent: %v":                         "❌ پاسخ ارسال نشد: %v",
+	"%s is blocklisted":                            "%s در فهرست مسدود است",
+	"No messages received in the last %d days":     "در %d روز گذشته پیامی دریافت نشده",
+	" • %d unread":                                 " • %d خوانده‌نشده",
+	"← %s • to %d":                                 "← %s • به %d",
+	"→ %s • from %d":                               "→ %s • از %d",
+	"💬 %s • line %s":                               "💬 %s • خط %s",
+	"↩️  Reply to %s":                              "↩️  پاسخ به %s",
+	"Sending... ⏳":                                 "در حال ارسال... ⏳",
+	"enter - Send reply":                           "enter - ارسال پاسخ",
+	"alt+enter - New line":                         "alt+enter - خط جدید",
+	"esc - Back to conversations":                  "esc - بازگشت به گفتگوها",
+	"y - Send anyway":                              "y - ارسال در هر صورت",
+	"n - Keep editing":                             "n - ادامه ویرایش",
+	"🕒 %s. Send anyway? y: send • n: keep editing": "🕒 %s. در هر صورت ارسال شود؟ y: ارسال • n: ادامه ویرایش",
+	"↑/↓ - Choose conversation":                    "↑/↓ - انتخاب گفتگو",
+	"enter - Reply":                                "enter - پاسخ",
+	"pgup/pgdn - Scroll":                           "pgup/pgdn - پیمایش",
+	"esc - Back":                                   "esc - بازگشت",
+
+	"Loading your lines...":    "در حال بارگذاری خطوط شما...",
+	"Could not load lines: %v": "بارگذاری خطوط ممکن نشد: %v",
+	"Type the line number...":  "شماره خط را بنویسید...",
+	"Tab: back to the list":    "Tab: بازگشت به فهرست",
+	"default":                  "پیش‌فرض",
+	"last used":                "آخرین استفاده",
+	"↑/↓: choose • Tab: type a line manually": "↑/↓: انتخاب • Tab: نوشتن شماره خط",
+
+	"SMS sending cancelled.\n": "ارسال پیامک لغو شد.\n",
+	"Message":                  "پیام",
+	"Mobiles":                  "موبایل‌ها",
+	"Enter your message text:": "متن پیام را وارد کنید:",
+	"Preview:":                 "پیش‌نمایش:",
+	"📏 %d chars • %s • %d part(s) • %d left in this part": "📏 %d نویسه • %s • %d بخش • %d نویسه باقی در این بخش",
+	" (incl. signature)":   " (با امضا)",
+	"Unicode because of: ": "یونیکد به خاطر: ",
+	"💰 Projected cost: %s SMS (%d part(s) × %d recipient(s))": "💰 هزینه پیش‌بینی‌شده: %s پیامک (%d بخش × %d گیرنده)",
+	"%s exceeds your credit of %.2f SMS":                      "%s از اعتبار شما (%.2f پیامک) بیشتر است",
+	"%s of %.2f SMS credit":                                   "%s از %.2f پیامک اعتبار",
+	"Enter mobile numbers (comma-separated):":                 "شماره‌های موبایل را وارد کنید (جداشده با ویرگول):",
+	"Choose the sender line:":                                 "خط فرستنده را انتخاب کنید:",
+	"Enter line number:":                                      "شماره خط را وارد کنید:",
+	"Enter line number (leave empty to use %s):":              "شماره خط را وارد کنید (برای استفاده از %s خالی بگذارید):",
+	"Confirm and Send:":                                       "تأیید و ارسال:",
+	"Line Number: Not set":                                    "شماره خط: تنظیم نشده",
+	"💡 Estimated cost: %s (%d part(s) × %d recipient(s))":     "💡 هزینه تخمینی: %s (%d بخش × %d گیرنده)",
+	"Message:":                                   "پیام:",
+	"Mobiles: %s":                                "موبایل‌ها: %s",
+	"Enter: continue":                            "Enter: ادامه",
+	"Alt+Enter: new line":                        "Alt+Enter: خط جدید",
+	"Ctrl+Z/Ctrl+Y: undo/redo":                   "Ctrl+Z/Ctrl+Y: واگرد/انجام دوباره",
+	"Ctrl+V: paste":                              "Ctrl+V: چسباندن",
+	"F2: %s":                                     "F2: %s",
+	"Esc or Ctrl+C: cancel":                      "Esc یا Ctrl+C: لغو",
+	"Press Enter to send SMS":                    "برای ارسال پیامک Enter را بزنید",
+	"Press F2 to %s":                             "F2: %s",
+	"leave RTL text to the terminal":             "چیدمان راست‌به‌چپ با ترمینال",
+	"reorder RTL text":                           "چیدمان راست‌به‌چپ با برنامه",
+	"✅ SMS sent successfully!":                   "✅ پیامک با موفقیت ارسال شد!",
+	"💬 Message:":                                 "💬 پیام:",
+	"📦 Pack ID: %s":                              "📦 شناسه بسته: %s",
+	"💰 Cost: %.2f SMS":                           "💰 هزینه: %.2f پیامک",
+	"📱 Message IDs: %v":                          "📱 شناسه‌های پیام: %v",
+	"📊 Total messages: %d":                       "📊 تعداد کل پیام‌ها: %d",
+	"Esc or Shift+Tab: back":                     "Esc یا Shift+Tab: بازگشت",
+	"1-3: edit a step":                           "۱ تا ۳: ویرایش یک مرحله",
+	"1-2: edit a step":                           "۱ تا ۲: ویرایش یک مرحله",
+	"Discard the unsent message?":                "پیام ارسال‌نشده دور ریخته شود؟",
+	"Discard the entered API key?":               "کلید API واردشده دور ریخته شود؟",
+	"y: discard • n: keep editing":               "y: دور ریختن • n: ادامه ویرایش",
+	"Press q or Ctrl+C to exit...":               "برای خروج q یا Ctrl+C را بزنید...",
+	"❌ Error sending SMS":                        "❌ خطا در ارسال پیامک",
+	"Could not load contacts: %v":                "بارگذاری مخاطبان ممکن نشد: %v",
+	"Could not load the blocklist: %v":           "بارگذاری فهرست مسدود ممکن نشد: %v",
+	"Pick contacts and groups:":                  "مخاطبان و گروه‌ها را انتخاب کنید:",
+	"👥 %d selected • %d recipient(s)":            "👥 %d انتخاب‌شده • %d گیرنده",
+	"⛔ %s: blocklisted, will be skipped":         "⛔ %s: مسدود است و نادیده گرفته می‌شود",
+	"⛔ %d blocklisted number(s) will be skipped": "⛔ %d شماره مسدود نادیده گرفته می‌شود",
+	"Type to search":                             "برای جستجو تایپ کنید",
+	"↑/↓: move":                                  "↑/↓: جابه‌جایی",
+	"Space: select":                              "Space: انتخاب",
+	"Tab: type numbers":                          "Tab: تایپ شماره‌ها",
+	"Ctrl+C: cancel":                             "Ctrl+C: لغو",
+	"Tab: pick from contacts":                    "Tab: انتخاب از مخاطبان",
+	"No contacts yet. Add some with: smsir contacts add <name> <mobile>": "هنوز مخاطبی ندارید. با این فرمان اضافه کنید: smsir contacts add <name> <mobile>",
+	"Type to search contacts and groups...":                              "برای جستجوی مخاطبان و گروه‌ها تایپ کنید...",
+	"No matching contacts":                                               "مخاطب منطبقی پیدا نشد",
+	"%d member(s)":                                                       "%d عضو",
+
+	"📡 Delivery Watch • Pack %s":                                   "📡 پیگیری تحویل • بسته %s",
+	"❌ Error: %v":                                                  "❌ خطا: %v",
+	"Loading delivery report... ⏳":                                 "در حال بارگذاری گزارش تحویل... ⏳",
+	"Next check in %s":                                             "بررسی بعدی تا %s دیگر",
+	"✅ All messages reached a final state":                         "✅ همه پیام‌ها به وضعیت نهایی رسیدند",
+	"⏰ Timed out before all messages reached a final state":        "⏰ مهلت پیش از رسیدن همه پیام‌ها به وضعیت نهایی تمام شد",
+	"✅ Delivered: %d   ❌ Failed: %d   ⏳ Pending: %d   📊 Total: %d": "✅ تحویل‌شده: %d   ❌ ناموفق: %d   ⏳ در انتظار: %d   📊 کل: %d",
+	"Last update: %s • %s":                                         "آخرین به‌روزرسانی: %s • %s",
+	"Press q or Ctrl+C to stop watching":                           "برای پایان پیگیری q یا Ctrl+C را بزنید",
+}
@@ -0,0 +1,191 @@
+package i18n
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Lang is a language the CLI can be shown in
+type Lang string
+
+const (
+	// English is the language the messages are written in
+	English Lang = "en"
+	// Persian is Farsi
+	Persian Lang = "fa"
+)
+
+// Languages returns the supported languages
+func Languages() []Lang {
+	return []Lang{English, Persian}
+}
+
+// catalogs holds the translations of each language, keyed by the English message
+var catalogs = map[Lang]map[string]string{
+	Persian: persian,
+}
+
+var (
+	current       = English
+	persianDigits bool
+)
+
+// ParseLang parses a language name such as "fa", "persian" or a locale like "fa_IR.UTF-8"
+func ParseLang(name string) (Lang, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	// Locales look like fa_IR.UTF-8 or en-US
+	if i := strings.IndexAny(name, "_-.@"); i > 0 {
+		name = name[:i]
+	}
+
+	switch name {
+	case "en", "english", "c", "posix":
+		return English, nil
+	case "fa", "fas", "per", "persian", "farsi":
+		return Persian, nil
+	}
+	return "", fmt.Errorf("unsupported language %q (use en or fa)", name)
+}
+
+// Detect returns the language from the --lang flag, else SMSIR_LANG, else
+// the locale. An unsupported locale means English; an unsupported flag or
+// SMSIR_LANG value is an error.
+func Detect(flag string) (Lang, error) {
+	if flag != "" {
+		return ParseLang(flag)
+	}
+	if env := os.Getenv("SMSIR_LANG"); env != "" {
+		lang, err := ParseLang(env)
+		if err != nil {
+			return "", fmt.Errorf("SMSIR_LANG: %w", err)
+		}
+		return lang, nil
+	}
+
+	// The first locale variable that is set decides, as in setlocale(3)
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale := os.Getenv(key); locale != "" {
+			if lang, err := ParseLang(locale); err == nil {
+				return lang, nil
+			}
+			return English, nil
+		}
+	}
+	return English, nil
+}
+
+// ParseDigits parses the --digits and SMSIR_DIGITS values: "persian" or "latin"
+func ParseDigits(name string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "persian", "fa":
+		return true, nil
+	case "latin", "en", "":
+		return false, nil
+	}
+	return false, fmt.Errorf("unsupported digits %q (use latin or persian)", name)
+}
+
+// SetLang sets the language messages are translated to
+func SetLang(lang Lang) {
+	current = lang
+}
+
+// CurrentLang returns the language messages are translated to
+func CurrentLang() Lang {
+	return current
+}
+
+// SetPersianDigits sets whether numbers are shown in Persian digits
+func SetPersianDigits(enabled bool) {
+	persianDigits = enabled
+}
+
+// T returns the translation of an English message, or the message itself
+// when the current language has no translation for it
+func T(msg string) string {
+	if translated, ok := catalogs[current][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Sprintf formats the translation of format. Numbers among the arguments
+// are shown in Persian digits when those are enabled.
+func Sprintf(format string, a ...interface{}) string {
+	return fmt.Sprintf(T(format), localizeArgs(a)...)
+}
+
+// Printf writes the formatted translation of format to standard output
+func Printf(format string, a ...interface{}) (int, error) {
+	return fmt.Printf(T(format), localizeArgs(a)...)
+}
+
+// Fprintf writes the formatted translation of format to w
+func Fprintf(w io.Writer, format string, a ...interface{}) (int, error) {
+	return fmt.Fprintf(w, T(format), localizeArgs(a)...)
+}
+
+// Errorf returns an error with the formatted translation of format; %w wraps as with fmt.Errorf
+func Errorf(format string, a ...interface{}) error {
+	return fmt.Errorf(T(format), localizeArgs(a)...)
+}
+
+// Digits replaces the ASCII digits of s with Persian digits when those are enabled
+func Digits(s string) string {
+	if !persianDigits {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return '۰' + r - '0'
+		}
+		return r
+	}, s)
+}
+
+// localizeArgs wraps the numeric arguments so they are printed in Persian
+// digits. Strings such as mobile numbers and pack IDs are left alone, since
+// they are meant to be copied.
+func localizeArgs(a []interface{}) []interface{} {
+	if !persianDigits {
+		return a
+	}
+
+	localized := make([]interface{}, len(a))
+	for i, arg := range a {
+		switch arg.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			localized[i] = number{arg}
+		default:
+			localized[i] = arg
+		}
+	}
+	return localized
+}
+
+// number is a numeric argument printed in Persian digits
+type number struct {
+	value interface{}
+}
+
+// Format formats the number with the verb, width and flags it was given
+func (n number) Format(f fmt.State, verb rune) {
+	var directive strings.Builder
+	directive.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			directive.WriteRune(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		fmt.Fprintf(&directive, "%d", width)
+	}
+	if precision, ok := f.Precision(); ok {
+		fmt.Fprintf(&directive, ".%d", precision)
+	}
+	directive.WriteRune(verb)
+
+	io.WriteString(f, Digits(fmt.Sprintf(directive.String(), n.value)))
+}
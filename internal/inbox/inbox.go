@@ -0,0 +1,198 @@
+package inbox
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/history"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/phone"
+)
+
+const (
+	// Window is how far back conversations are loaded
+	Window = 30 * 24 * time.Hour
+	// pageSize is how many received messages are fetched per request
+	pageSize = 100
+	// maxPages bounds the archive pages fetched in one load
+	maxPages = 20
+)
+
+// Message is one message of a conversation, received or sent
+type Message struct {
+	Time     time.Time
+	Line     int64
+	Text     string
+	Outgoing bool
+}
+
+// Thread is the conversation with one mobile number, oldest message first
+type Thread struct {
+	Mobile   string
+	Messages []Message
+}
+
+// Last returns the latest message of the thread
+func (t *Thread) Last() Message {
+	return t.Messages[len(t.Messages)-1]
+}
+
+// Line returns the line the customer last wrote to; replies are sent from it
+func (t *Thread) Line() int64 {
+	for i := len(t.Messages) - 1; i >= 0; i-- {
+		if !t.Messages[i].Outgoing {
+			return t.Messages[i].Line
+		}
+	}
+	return t.Last().Line
+}
+
+// LastReceived returns when the customer last wrote
+func (t *Thread) LastReceived() time.Time {
+	for i := len(t.Messages) - 1; i >= 0; i-- {
+		if !t.Messages[i].Outgoing {
+			return t.Messages[i].Time
+		}
+	}
+	return time.Time{}
+}
+
+// Unread counts the received messages newer than readAt
+func (t *Thread) Unread(readAt time.Time) int {
+	unread := 0
+	for _, msg := range t.Messages {
+		if !msg.Outgoing && msg.Time.After(readAt) {
+			unread++
+		}
+	}
+	return unread
+}
+
+// Load fetches the messages received in the last Window and builds the
+// conversations with the sends of profile from the local history
+func Load(client *api.Client, profile string) ([]Thread, error) {
+	since := time.Now().Add(-Window)
+	received, err := Fetch(client, since)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := history.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	records, err := store.List(history.Filter{Since: since})
+	if err != nil {
+		return nil, err
+	}
+	sent := records[:0]
+	for _, r := range records {
+		if r.Profile == profile {
+			sent = append(sent, r)
+		}
+	}
+
+	return Build(received, sent), nil
+}
+
+// Fetch returns the messages received since the given time from the receive
+// archive and the latest messages, without duplicates. The live endpoint is
+// not used, since it hands every message out only once.
+func Fetch(client *api.Client, since time.Time) ([]api.ReceivedMessage, error) {
+	var received []api.ReceivedMessage
+	seen := make(map[string]bool)
+	add := func(messages []api.ReceivedMessage) {
+		for _, msg := range messages {
+			key := fmt.Sprintf("%d|%d|%d|%s", msg.Mobile, msg.Number, msg.ReceivedDateTime, msg.MessageText)
+			if seen[key] || msg.ReceivedDateTime < since.Unix() {
+				continue
+			}
+			seen[key] = true
+			received = append(received, msg)
+		}
+	}
+
+	toDate := time.Now().Unix()
+	for page := 1; page <= maxPages; page++ {
+		resp, err := client.GetReceivedArchive(page, pageSize, since.Unix(), toDate)
+		if err != nil {
+			return nil, i18n.Errorf("error getting received messages: %w", err)
+		}
+		if !resp.IsSuccess() {
+			return nil, i18n.Errorf("API error: %s", resp.GetStatusMessage())
+		}
+		add(resp.Data)
+		if len(resp.Data) < pageSize {
+			break
+		}
+	}
+
+	// The archive may lag behind the messages that just came in
+	resp, err := client.GetLatestReceived(pageSize)
+	if err != nil {
+		return nil, i18n.Errorf("error getting received messages: %w", err)
+	}
+	if !resp.IsSuccess() {
+		return nil, i18n.Errorf("API error: %s", resp.GetStatusMessage())
+	}
+	add(resp.Data)
+
+	return received, nil
+}
+
+// Build groups received messages by mobile number and merges in the sends to
+// those numbers that went out already, latest conversation first. Only
+// numbers that wrote in get a thread; sends to other numbers are left out.
+func Build(received []api.ReceivedMessage, sent []history.Record) []Thread {
+	threads := make(map[string]*Thread)
+	for _, msg := range received {
+		mobile := phone.FromInt(msg.Mobile)
+		t, ok := threads[mobile]
+		if !ok {
+			t = &Thread{Mobile: mobile}
+			threads[mobile] = t
+		}
+		t.Messages = append(t.Messages, Message{
+			Time: time.Unix(msg.ReceivedDateTime, 0),
+			Line: msg.Number,
+			Text: msg.MessageText,
+		})
+	}
+
+	now := time.Now()
+	for _, r := range sent {
+		at := r.Timestamp
+		if !r.SendAt.IsZero() {
+			at = r.SendAt
+		}
+		if at.After(now) {
+			// Scheduled and not gone out yet
+			continue
+		}
+		for _, recipient := range r.Recipients {
+			mobile, err := phone.Normalize(recipient)
+			if err != nil {
+				continue
+			}
+			if t, ok := threads[mobile]; ok {
+				t.Messages = append(t.Messages, Message{Time: at, Line: r.LineNumber, Text: r.MessageText, Outgoing: true})
+			}
+		}
+	}
+
+	list := make([]Thread, 0, len(threads))
+	for _, t := range threads {
+		sort.SliceStable(t.Messages, func(i, j int) bool {
+			return t.Messages[i].Time.Before(t.Messages[j].Time)
+		})
+		list = append(list, *t)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Last().Time.After(list[j].Last().Time)
+	})
+	return list
+}
@@ -0,0 +1,190 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format selected with --output
+type Format string
+
+const (
+	// FormatText is the default human-readable output
+	FormatText  Format = ""
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatCSV   Format = "csv"
+	FormatTSV   Format = "tsv"
+)
+
+// Formats lists the accepted --output values
+var Formats = []Format{FormatTable, FormatJSON, FormatYAML, FormatCSV, FormatTSV}
+
+// Result is the output of a command in every supported shape
+type Result struct {
+	// Data is encoded for json and yaml; its json tags define the field names
+	Data interface{}
+	// Columns and Rows are used for table, csv and tsv
+	Columns []string
+	Rows    [][]string
+	// Text writes the default human-readable output; the table is used when nil
+	Text func(w io.Writer)
+	// Values are printed one per line with --quiet
+	Values []string
+}
+
+// Renderer writes command results in the selected format
+type Renderer struct {
+	format Format
+	quiet  bool
+	w      io.Writer
+}
+
+// New creates a renderer, validating the format name
+func New(format string, quiet bool, w io.Writer) (*Renderer, error) {
+	f := Format(strings.ToLower(format))
+	if f != FormatText {
+		valid := false
+		for _, known := range Formats {
+			if f == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			names := make([]string, len(Formats))
+			for i, known := range Formats {
+				names[i] = string(known)
+			}
+			return nil, i18n.Errorf("unknown output format %q (use %s)", format, strings.Join(names, ", "))
+		}
+	}
+
+	return &Renderer{format: f, quiet: quiet, w: w}, nil
+}
+
+// Format returns the selected format
+func (r *Renderer) Format() Format {
+	return r.format
+}
+
+// Structured reports whether output is meant for scripts, in which case
+// commands should send notices and progress to stderr instead of stdout
+func (r *Renderer) Structured() bool {
+	return r.quiet || r.format != FormatText
+}
+
+// Render writes the result in the selected format
+func (r *Renderer) Render(res Result) error {
+	if r.quiet {
+		for _, v := range res.Values {
+			fmt.Fprintln(r.w, v)
+		}
+		return nil
+	}
+
+	switch r.format {
+	case FormatJSON:
+		enc := json.NewEncoder(r.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res.Data)
+
+	case FormatYAML:
+		// Round-trip through JSON so YAML keys match the JSON field names
+		generic, err := toGeneric(res.Data)
+		if err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(r.w)
+		enc.SetIndent(2)
+		if err := enc.Encode(generic); err != nil {
+			return i18n.Errorf("failed to encode yaml: %w", err)
+		}
+		return enc.Close()
+
+	case FormatCSV, FormatTSV:
+		cw := csv.NewWriter(r.w)
+		if r.format == FormatTSV {
+			cw.Comma = '\t'
+		}
+		if err := cw.Write(res.Columns); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(res.Rows); err != nil {
+			return err
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case FormatText:
+		if res.Text != nil {
+			res.Text(r.w)
+			return nil
+		}
+	}
+
+	return r.renderTable(res)
+}
+
+// renderTable writes columns and rows aligned with spaces. Unlike csv and
+// tsv, the table is for people, so its headers are translated.
+func (r *Renderer) renderTable(res Result) error {
+	tw := tabwriter.NewWriter(r.w, 0, 0, 2, ' ', 0)
+	columns := make([]string, len(res.Columns))
+	for i, column := range res.Columns {
+		columns[i] = i18n.T(column)
+	}
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	for _, row := range res.Rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// toGeneric converts v into maps and slices using its JSON encoding
+func toGeneric(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, i18n.Errorf("failed to marshal output: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, i18n.Errorf("failed to unmarshal output: %w", err)
+	}
+	return fromNumbers(generic), nil
+}
+
+// fromNumbers replaces json.Number values with int64 or float64 so that
+// large IDs and line numbers are not written in exponent form
+func fromNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = fromNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = fromNumbers(item)
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	}
+	return v
+}
@@ -0,0 +1,144 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		format  string
+		want    Format
+		wantErr bool
+	}{
+		{format: "", want: FormatText},
+		{format: "table", want: FormatTable},
+		{format: "JSON", want: FormatJSON},
+		{format: "yaml", want: FormatYAML},
+		{format: "csv", want: FormatCSV},
+		{format: "tsv", want: FormatTSV},
+		{format: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		r, err := New(tt.format, false, io.Discard)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("New(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+		}
+		if err == nil && r.Format() != tt.want {
+			t.Errorf("New(%q).Format() = %q, want %q", tt.format, r.Format(), tt.want)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	type line struct {
+		Number int64  `json:"number"`
+		Label  string `json:"label"`
+	}
+	res := Result{
+		Data:    []line{{Number: 300012345678, Label: "otp"}, {Number: 3000999, Label: "a, b"}},
+		Columns: []string{"NUMBER", "LABEL"},
+		Rows:    [][]string{{"300012345678", "otp"}, {"3000999", "a, b"}},
+		Values:  []string{"300012345678", "3000999"},
+	}
+	withText := res
+	withText.Text = func(w io.Writer) { fmt.Fprintln(w, "2 lines") }
+
+	tests := []struct {
+		name   string
+		format string
+		quiet  bool
+		res    Result
+		want   string
+	}{
+		{
+			name: "text",
+			res:  withText,
+			want: "2 lines\n",
+		},
+		{
+			name: "text falls back to the table",
+			res:  res,
+			want: "NUMBER        LABEL\n300012345678  otp\n3000999       a, b\n",
+		},
+		{
+			name:   "table ignores the text",
+			format: "table",
+			res:    withText,
+			want:   "NUMBER        LABEL\n300012345678  otp\n3000999       a, b\n",
+		},
+		{
+			name:   "json",
+			format: "json",
+			res:    res,
+			want:   "[\n  {\n    \"number\": 300012345678,\n    \"label\": \"otp\"\n  },\n  {\n    \"number\": 3000999,\n    \"label\": \"a, b\"\n  }\n]\n",
+		},
+		{
+			name:   "yaml keeps large numbers",
+			format: "yaml",
+			res:    res,
+			want:   "- label: otp\n  number: 300012345678\n- label: a, b\n  number: 3000999\n",
+		},
+		{
+			name:   "csv",
+			format: "csv",
+			res:    res,
+			want:   "NUMBER,LABEL\n300012345678,otp\n3000999,\"a, b\"\n",
+		},
+		{
+			name:   "tsv",
+			format: "tsv",
+			res:    res,
+			want:   "NUMBER\tLABEL\n300012345678\totp\n3000999\ta, b\n",
+		},
+		{
+			name:   "quiet wins over the format",
+			format: "json",
+			quiet:  true,
+			res:    res,
+			want:   "300012345678\n3000999\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			r, err := New(tt.format, tt.quiet, &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := r.Render(tt.res); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Render() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructured(t *testing.T) {
+	tests := []struct {
+		format string
+		quiet  bool
+		want   bool
+	}{
+		{"", false, false},
+		{"", true, true},
+		{"table", false, true},
+		{"json", false, true},
+	}
+
+	for _, tt := range tests {
+		r, err := New(tt.format, tt.quiet, io.Discard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := r.Structured(); got != tt.want {
+			t.Errorf("Structured() with format %q, quiet %v = %v, want %v", tt.format, tt.quiet, got, tt.want)
+		}
+	}
+}
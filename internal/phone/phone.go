@@ -0,0 +1,91 @@
+package phone
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+)
+
+// Normalize converts an Iranian mobile number to the canonical 09XXXXXXXXX form.
+// It accepts Persian and Arabic digits, +98/0098/98 prefixes and separators.
+func Normalize(mobile string) (string, error) {
+	var digits strings.Builder
+	for _, r := range mobile {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r >= '۰' && r <= '۹':
+			digits.WriteRune('0' + (r - '۰'))
+		case r >= '٠' && r <= '٩':
+			digits.WriteRune('0' + (r - '٠'))
+		case r == '+' || r == '-' || r == ' ' || r == '(' || r == ')':
+			// Separators are ignored
+		default:
+			return "", i18n.Errorf("invalid character %q in mobile number", r)
+		}
+	}
+
+	number := digits.String()
+	switch {
+	case strings.HasPrefix(number, "0098"):
+		number = "0" + number[4:]
+	case strings.HasPrefix(number, "98") && len(number) == 12:
+		number = "0" + number[2:]
+	case strings.HasPrefix(number, "9") && len(number) == 10:
+		number = "0" + number
+	}
+
+	if len(number) != 11 || !strings.HasPrefix(number, "09") {
+		return "", i18n.Errorf("invalid mobile number: %s", mobile)
+	}
+
+	return number, nil
+}
+
+// FromInt formats a mobile number returned by the API as an int64
+func FromInt(mobile int64) string {
+	number, err := Normalize(fmt.Sprintf("%d", mobile))
+	if err != nil {
+		return fmt.Sprintf("%d", mobile)
+	}
+	return number
+}
+
+// Mobile operators, as used for routing recipients to lines
+const (
+	OperatorMCI      = "mci"
+	OperatorIrancell = "irancell"
+	OperatorRightel  = "rightel"
+)
+
+// operatorPrefixes maps the first four digits of a mobile number to its operator
+var operatorPrefixes = map[string]string{
+	"0910": OperatorMCI, "0911": OperatorMCI, "0912": OperatorMCI, "0913": OperatorMCI,
+	"0914": OperatorMCI, "0915": OperatorMCI, "0916": OperatorMCI, "0917": OperatorMCI,
+	"0918": OperatorMCI, "0919": OperatorMCI, "0990": OperatorMCI, "0991": OperatorMCI,
+	"0992": OperatorMCI, "0993": OperatorMCI, "0994": OperatorMCI,
+
+	"0900": OperatorIrancell, "0901": OperatorIrancell, "0902": OperatorIrancell,
+	"0903": OperatorIrancell, "0904": OperatorIrancell, "0905": OperatorIrancell,
+	"0930": OperatorIrancell, "0933": OperatorIrancell, "0935": OperatorIrancell,
+	"0936": OperatorIrancell, "0937": OperatorIrancell, "0938": OperatorIrancell,
+	"0939": OperatorIrancell, "0941": OperatorIrancell,
+
+	"0920": OperatorRightel, "0921": OperatorRightel, "0922": OperatorRightel,
+	"0923": OperatorRightel,
+}
+
+// Operators returns the operators Operator can detect
+func Operators() []string {
+	return []string{OperatorMCI, OperatorIrancell, OperatorRightel}
+}
+
+// Operator returns the operator of a mobile number, or "" when it is unknown
+func Operator(mobile string) string {
+	number, err := Normalize(mobile)
+	if err != nil {
+		return ""
+	}
+	return operatorPrefixes[number[:4]]
+}
@@ -0,0 +1,57 @@
+package phone
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		mobile  string
+		want    string
+		wantErr bool
+	}{
+		{name: "canonical", mobile: "09121234567", want: "09121234567"},
+		{name: "without leading zero", mobile: "9121234567", want: "09121234567"},
+		{name: "plus prefix", mobile: "+989121234567", want: "09121234567"},
+		{name: "double zero prefix", mobile: "00989121234567", want: "09121234567"},
+		{name: "country code", mobile: "989121234567", want: "09121234567"},
+		{name: "separators", mobile: "0912 123-45 (67)", want: "09121234567"},
+		{name: "persian digits", mobile: "۰۹۱۲۱۲۳۴۵۶۷", want: "09121234567"},
+		{name: "arabic digits", mobile: "٠٩١٢١٢٣٤٥٦٧", want: "09121234567"},
+		{name: "letters", mobile: "0912abc4567", wantErr: true},
+		{name: "too short", mobile: "0912123456", wantErr: true},
+		{name: "too long", mobile: "091212345678", wantErr: true},
+		{name: "landline", mobile: "02112345678", wantErr: true},
+		{name: "empty", mobile: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.mobile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize(%q) error = %v, wantErr %v", tt.mobile, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.mobile, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperator(t *testing.T) {
+	tests := []struct {
+		mobile string
+		want   string
+	}{
+		{"09121234567", OperatorMCI},
+		{"+989351234567", OperatorIrancell},
+		{"09211234567", OperatorRightel},
+		{"09991234567", ""},
+		{"invalid", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Operator(tt.mobile); got != tt.want {
+			t.Errorf("Operator(%q) = %q, want %q", tt.mobile, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package routing
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/phone"
+)
+
+// Strategy decides which line each recipient of a send is sent from
+type Strategy string
+
+const (
+	// RoundRobin deals the recipients out over the lines in turn
+	RoundRobin Strategy = "round-robin"
+	// Weighted deals the recipients out in proportion to the line weights
+	Weighted Strategy = "weighted"
+	// Operator sends to each recipient from a line of the recipient's operator
+	Operator Strategy = "operator"
+)
+
+// Strategies returns the supported strategies
+func Strategies() []Strategy {
+	return []Strategy{RoundRobin, Weighted, Operator}
+}
+
+// ParseStrategy parses a strategy name
+func ParseStrategy(name string) (Strategy, error) {
+	for _, s := range Strategies() {
+		if string(s) == name {
+			return s, nil
+		}
+	}
+	return "", i18n.Errorf("unknown strategy %q (use round-robin, weighted or operator)", name)
+}
+
+// Batch is the part of a send that goes out from one line
+type Batch struct {
+	Line    config.Line
+	Mobiles []string
+}
+
+// ParseLines resolves line specs such as "otp", "3000123" or "marketing:3",
+// where the number after the colon overrides the line's weight
+func ParseLines(cfg *config.Config, specs []string) ([]config.Line, error) {
+	lines := make([]config.Line, 0, len(specs))
+	seen := make(map[string]bool)
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		name, weight, hasWeight := strings.Cut(spec, ":")
+		line, err := cfg.ResolveLine(name)
+		if err != nil {
+			return nil, err
+		}
+		if hasWeight {
+			w, err := strconv.Atoi(weight)
+			if err != nil || w < 1 {
+				return nil, i18n.Errorf("invalid weight %q for line %s", weight, name)
+			}
+			line.Weight = w
+		}
+
+		if seen[line.Number] {
+			return nil, i18n.Errorf("line %s is listed more than once", line.Label())
+		}
+		seen[line.Number] = true
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return nil, i18n.Errorf("at least one line is required")
+	}
+	return lines, nil
+}
+
+// Split divides the recipients between the lines. Lines that get no
+// recipients are left out of the result; the order of lines is kept.
+func Split(strategy Strategy, lines []config.Line, mobiles []string) ([]Batch, error) {
+	var assign []int
+	var err error
+	switch strategy {
+	case RoundRobin:
+		weights := make([]int, len(lines))
+		for i := range weights {
+			weights[i] = 1
+		}
+		assign = deal(weights, len(mobiles))
+	case Weighted:
+		weights := make([]int, len(lines))
+		for i, line := range lines {
+			// Lines without a weight count once
+			weights[i] = max(line.Weight, 1)
+		}
+		assign = deal(weights, len(mobiles))
+	case Operator:
+		assign, err = byOperator(lines, mobiles)
+	default:
+		err = i18n.Errorf("unknown strategy %q", strategy)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	perLine := make([][]string, len(lines))
+	for i, mobile := range mobiles {
+		perLine[assign[i]] = append(perLine[assign[i]], mobile)
+	}
+
+	var batches []Batch
+	for i, line := range lines {
+		if len(perLine[i]) > 0 {
+			batches = append(batches, Batch{Line: line, Mobiles: perLine[i]})
+		}
+	}
+	return batches, nil
+}
+
+// deal assigns n recipients to lines with smooth weighted round-robin, so the
+// lines take turns in proportion to their weights instead of in blocks.
+// Every weight must be positive.
+func deal(weights []int, n int) []int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+
+	current := make([]int, len(weights))
+	assign := make([]int, n)
+	for i := range assign {
+		best := -1
+		for j, w := range weights {
+			current[j] += w
+			if best < 0 || current[j] > current[best] {
+				best = j
+			}
+		}
+		current[best] -= total
+		assign[i] = best
+	}
+	return assign
+}
+
+// byOperator assigns each recipient to the lines set up for its operator,
+// taking turns when an operator has several lines. Recipients whose operator
+// has no line go to the lines without an operator, or to all lines if every
+// line has one.
+func byOperator(lines []config.Line, mobiles []string) ([]int, error) {
+	groups := make(map[string][]int)
+	for i, line := range lines {
+		groups[line.Operator] = append(groups[line.Operator], i)
+	}
+	if len(groups) == 1 && groups[""] != nil {
+		return nil, i18n.Errorf("no line has an operator; set one with 'smsir lines alias set <name> <number> --operator mci|irancell|rightel'")
+	}
+
+	fallback := groups[""]
+	if len(fallback) == 0 {
+		fallback = make([]int, len(lines))
+		for i := range fallback {
+			fallback[i] = i
+		}
+	}
+
+	next := make(map[string]int)
+	assign := make([]int, len(mobiles))
+	for i, mobile := range mobiles {
+		op := phone.Operator(mobile)
+		group, ok := groups[op]
+		if !ok || op == "" {
+			op, group = "", fallback
+		}
+		assign[i] = group[next[op]%len(group)]
+		next[op]++
+	}
+	return assign, nil
+}
@@ -0,0 +1,201 @@
+package routing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/phone"
+)
+
+func TestDeal(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []int
+		n       int
+		want    []int
+	}{
+		{name: "equal weights take turns", weights: []int{1, 1}, n: 5, want: []int{0, 1, 0, 1, 0}},
+		{name: "heavier line is spread out", weights: []int{2, 1}, n: 6, want: []int{0, 1, 0, 0, 1, 0}},
+		{name: "three lines", weights: []int{3, 1, 1}, n: 5, want: []int{0, 1, 0, 2, 0}},
+		{name: "single line", weights: []int{4}, n: 3, want: []int{0, 0, 0}},
+		{name: "no recipients", weights: []int{1, 1}, n: 0, want: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deal(tt.weights, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("deal(%v, %d) = %v, want %v", tt.weights, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestByOperator(t *testing.T) {
+	const (
+		mci      = "09121110000"
+		irancell = "09351110000"
+		rightel  = "09211110000"
+	)
+
+	tests := []struct {
+		name      string
+		operators []string
+		mobiles   []string
+		want      []int
+		wantErr   bool
+	}{
+		{
+			name:      "line per operator",
+			operators: []string{phone.OperatorMCI, phone.OperatorIrancell},
+			mobiles:   []string{mci, irancell, mci},
+			want:      []int{0, 1, 0},
+		},
+		{
+			name:      "operator without a line uses the lines without an operator",
+			operators: []string{phone.OperatorMCI, ""},
+			mobiles:   []string{rightel, mci, irancell},
+			want:      []int{1, 0, 1},
+		},
+		{
+			name:      "every line has an operator",
+			operators: []string{phone.OperatorMCI, phone.OperatorIrancell},
+			mobiles:   []string{rightel, rightel, rightel},
+			want:      []int{0, 1, 0},
+		},
+		{
+			name:      "lines of one operator take turns",
+			operators: []string{phone.OperatorMCI, phone.OperatorMCI, phone.OperatorIrancell},
+			mobiles:   []string{mci, mci, irancell, mci},
+			want:      []int{0, 1, 2, 0},
+		},
+		{
+			name:      "no operators",
+			operators: []string{"", ""},
+			mobiles:   []string{mci},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := make([]config.Line, len(tt.operators))
+			for i, op := range tt.operators {
+				lines[i] = config.Line{Number: "300012" + string(rune('0'+i)), Operator: op}
+			}
+
+			got, err := byOperator(lines, tt.mobiles)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("byOperator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("byOperator() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	a := config.Line{Number: "3000121"}
+	b := config.Line{Number: "3000122", Weight: 3}
+	mobiles := []string{"09121110000", "09121110001", "09121110002", "09121110003"}
+
+	tests := []struct {
+		name     string
+		strategy Strategy
+		lines    []config.Line
+		mobiles  []string
+		want     []Batch
+		wantErr  bool
+	}{
+		{
+			name:     "round robin ignores weights",
+			strategy: RoundRobin,
+			lines:    []config.Line{a, b},
+			mobiles:  mobiles,
+			want: []Batch{
+				{Line: a, Mobiles: []string{mobiles[0], mobiles[2]}},
+				{Line: b, Mobiles: []string{mobiles[1], mobiles[3]}},
+			},
+		},
+		{
+			name:     "weighted counts a missing weight once",
+			strategy: Weighted,
+			lines:    []config.Line{a, b},
+			mobiles:  mobiles,
+			want: []Batch{
+				{Line: a, Mobiles: []string{mobiles[1]}},
+				{Line: b, Mobiles: []string{mobiles[0], mobiles[2], mobiles[3]}},
+			},
+		},
+		{
+			name:     "lines without recipients are left out",
+			strategy: RoundRobin,
+			lines:    []config.Line{a, b},
+			mobiles:  mobiles[:1],
+			want:     []Batch{{Line: a, Mobiles: mobiles[:1]}},
+		},
+		{
+			name:     "unknown strategy",
+			strategy: "random",
+			lines:    []config.Line{a},
+			mobiles:  mobiles,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Split(tt.strategy, tt.lines, tt.mobiles)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLines(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Lines = map[string]config.Line{
+		"otp":       {Number: "3000123", Weight: 1},
+		"marketing": {Number: "3000999", Weight: 2},
+	}
+
+	tests := []struct {
+		name    string
+		specs   []string
+		want    []config.Line
+		wantErr bool
+	}{
+		{
+			name:  "aliases and numbers",
+			specs: []string{"otp", " 3000555 ", ""},
+			want:  []config.Line{{Name: "otp", Number: "3000123", Weight: 1}, {Number: "3000555"}},
+		},
+		{
+			name:  "weight override",
+			specs: []string{"marketing:5"},
+			want:  []config.Line{{Name: "marketing", Number: "3000999", Weight: 5}},
+		},
+		{name: "zero weight", specs: []string{"otp:0"}, wantErr: true},
+		{name: "weight that is not a number", specs: []string{"otp:high"}, wantErr: true},
+		{name: "same line twice", specs: []string{"otp", "3000123"}, wantErr: true},
+		{name: "unknown alias", specs: []string{"support"}, wantErr: true},
+		{name: "no lines", specs: []string{" "}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLines(cfg, tt.specs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLines(%q) error = %v, wantErr %v", tt.specs, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLines(%q) = %+v, want %+v", tt.specs, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package secret
+
+import (
+	"os"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+)
+
+// EnvBackend reads secrets from environment variables and never stores them;
+// the account is the name of the variable, e.g. SMSIR_API_KEY
+type EnvBackend struct{}
+
+// Name returns the backend name
+func (EnvBackend) Name() string {
+	return BackendEnv
+}
+
+// Get returns the value of the environment variable named by account
+func (EnvBackend) Get(account string) (string, error) {
+	value := os.Getenv(account)
+	if value == "" {
+		return "", i18n.Errorf("%w: environment variable %s is not set", ErrNotFound, account)
+	}
+	return value, nil
+}
+
+// Set fails unless the environment variable already holds the value, as the
+// secret must be provided in the environment
+func (EnvBackend) Set(account, value string) error {
+	if os.Getenv(account) == value {
+		return nil
+	}
+	return i18n.Errorf("the env backend is read-only; set %s", account)
+}
+
+// Delete does nothing
+func (EnvBackend) Delete(account string) error {
+	return nil
+}
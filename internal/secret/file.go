@@ -0,0 +1,183 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"os"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// secretFilePerms are the permissions of the encrypted secrets file
+	secretFilePerms = 0600
+	// scrypt parameters for deriving the encryption key from the passphrase
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 16
+)
+
+// FileBackend stores secrets in a file encrypted with AES-256-GCM, using a
+// key derived from a passphrase with scrypt
+type FileBackend struct {
+	Path string
+	// Passphrase returns the passphrase; it is called at most once per backend
+	Passphrase func() (string, error)
+
+	passphrase string
+}
+
+// encryptedFile is the on-disk format of the secrets file
+type encryptedFile struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// Name returns the backend name
+func (b *FileBackend) Name() string {
+	return BackendFile
+}
+
+// Get returns the secret stored for an account
+func (b *FileBackend) Get(account string) (string, error) {
+	secrets, err := b.load()
+	if err != nil {
+		return "", err
+	}
+	value, ok := secrets[account]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+// Set stores the secret for an account
+func (b *FileBackend) Set(account, value string) error {
+	secrets, err := b.load()
+	if err != nil {
+		return err
+	}
+	secrets[account] = value
+	return b.save(secrets)
+}
+
+// Delete removes the secret of an account
+func (b *FileBackend) Delete(account string) error {
+	secrets, err := b.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[account]; !ok {
+		return nil
+	}
+	delete(secrets, account)
+	return b.save(secrets)
+}
+
+// Check decrypts the secrets file to verify the passphrase; a missing file passes
+func (b *FileBackend) Check() error {
+	_, err := b.load()
+	return err
+}
+
+// load decrypts the secrets file; a missing file holds no secrets
+func (b *FileBackend) load() (map[string]string, error) {
+	secrets := make(map[string]string)
+
+	data, err := os.ReadFile(b.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("failed to read secrets file: %w", err)
+	}
+
+	var f encryptedFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, i18n.Errorf("failed to unmarshal secrets file: %w", err)
+	}
+
+	gcm, err := b.cipher(f.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := gcm.Open(nil, f.Nonce, f.Data, nil)
+	if err != nil {
+		return nil, i18n.Errorf("failed to decrypt secrets file: wrong passphrase?")
+	}
+
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, i18n.Errorf("failed to unmarshal secrets: %w", err)
+	}
+	return secrets, nil
+}
+
+// save encrypts the secrets with a fresh salt and nonce and writes the file
+func (b *FileBackend) save(secrets map[string]string) error {
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return i18n.Errorf("failed to marshal secrets: %w", err)
+	}
+
+	f := encryptedFile{Salt: make([]byte, saltLen)}
+	if _, err := rand.Read(f.Salt); err != nil {
+		return i18n.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := b.cipher(f.Salt)
+	if err != nil {
+		return err
+	}
+
+	f.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return i18n.Errorf("failed to generate nonce: %w", err)
+	}
+	f.Data = gcm.Seal(nil, f.Nonce, plain, nil)
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return i18n.Errorf("failed to marshal secrets file: %w", err)
+	}
+
+	if err := os.WriteFile(b.Path, data, secretFilePerms); err != nil {
+		return i18n.Errorf("failed to write secrets file: %w", err)
+	}
+	return os.Chmod(b.Path, secretFilePerms)
+}
+
+// cipher derives the AES-GCM cipher for a salt from the passphrase
+func (b *FileBackend) cipher(salt []byte) (cipher.AEAD, error) {
+	if b.passphrase == "" {
+		if b.Passphrase == nil {
+			return nil, i18n.Errorf("no passphrase available for the secrets file")
+		}
+		passphrase, err := b.Passphrase()
+		if err != nil {
+			return nil, err
+		}
+		if passphrase == "" {
+			return nil, i18n.Errorf("passphrase is required for the secrets file")
+		}
+		b.passphrase = passphrase
+	}
+
+	key, err := scrypt.Key([]byte(b.passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, i18n.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,113 @@
+package secret
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newFileBackend returns a backend for path that always answers passphrase
+func newFileBackend(path, passphrase string) *FileBackend {
+	return &FileBackend{
+		Path:       path,
+		Passphrase: func() (string, error) { return passphrase, nil },
+	}
+}
+
+func TestFileBackendRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+
+	b := newFileBackend(path, "correct horse")
+	if err := b.Check(); err != nil {
+		t.Fatalf("Check() on a missing file = %v, want nil", err)
+	}
+	for account, value := range map[string]string{"default": "key-1", "marketing": "key-2"} {
+		if err := b.Set(account, value); err != nil {
+			t.Fatalf("Set(%q) error = %v", account, err)
+		}
+	}
+	if err := b.Delete("marketing"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != secretFilePerms {
+		t.Errorf("secrets file permissions = %04o, want %04o", perm, secretFilePerms)
+	}
+
+	// A new backend reads the file with the same passphrase only
+	tests := []struct {
+		name       string
+		passphrase string
+		account    string
+		want       string
+		wantErr    bool
+		notFound   bool
+	}{
+		{name: "stored secret", passphrase: "correct horse", account: "default", want: "key-1"},
+		{name: "deleted secret", passphrase: "correct horse", account: "marketing", wantErr: true, notFound: true},
+		{name: "unknown account", passphrase: "correct horse", account: "other", wantErr: true, notFound: true},
+		{name: "wrong passphrase", passphrase: "battery staple", account: "default", wantErr: true},
+		{name: "empty passphrase", passphrase: "", account: "default", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFileBackend(path, tt.passphrase).Get(tt.account)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get(%q) error = %v, wantErr %v", tt.account, err, tt.wantErr)
+			}
+			if errors.Is(err, ErrNotFound) != tt.notFound {
+				t.Errorf("Get(%q) error = %v, want ErrNotFound %v", tt.account, err, tt.notFound)
+			}
+			if got != tt.want {
+				t.Errorf("Get(%q) = %q, want %q", tt.account, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileBackendCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	if err := newFileBackend(path, "correct horse").Set("default", "key-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		passphrase string
+		wantErr    bool
+	}{
+		{"correct horse", false},
+		{"battery staple", true},
+	}
+
+	for _, tt := range tests {
+		if err := newFileBackend(path, tt.passphrase).Check(); (err != nil) != tt.wantErr {
+			t.Errorf("Check() with passphrase %q error = %v, wantErr %v", tt.passphrase, err, tt.wantErr)
+		}
+	}
+}
+
+func TestEnvBackendSet(t *testing.T) {
+	t.Setenv("SMSIR_TEST_KEY", "key-1")
+
+	tests := []struct {
+		account string
+		value   string
+		wantErr bool
+	}{
+		{"SMSIR_TEST_KEY", "key-1", false},
+		{"SMSIR_TEST_KEY", "key-2", true},
+		{"SMSIR_TEST_UNSET", "key-1", true},
+	}
+
+	for _, tt := range tests {
+		if err := (EnvBackend{}).Set(tt.account, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Set(%q, %q) error = %v, wantErr %v", tt.account, tt.value, err, tt.wantErr)
+		}
+	}
+}
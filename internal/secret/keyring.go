@@ -0,0 +1,48 @@
+package secret
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringService is the service name secrets are stored under in the OS keyring
+const keyringService = "smsir-cli"
+
+// KeyringBackend stores secrets in the OS keyring: Secret Service on Linux,
+// Keychain on macOS and Credential Manager on Windows
+type KeyringBackend struct{}
+
+// Name returns the backend name
+func (KeyringBackend) Name() string {
+	return BackendKeyring
+}
+
+// Available reports whether an OS keyring can be reached
+func (KeyringBackend) Available() bool {
+	_, err := keyring.Get(keyringService, "__probe__")
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
+
+// Get returns the secret stored for an account
+func (KeyringBackend) Get(account string) (string, error) {
+	value, err := keyring.Get(keyringService, account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return value, err
+}
+
+// Set stores the secret for an account
+func (KeyringBackend) Set(account, value string) error {
+	return keyring.Set(keyringService, account, value)
+}
+
+// Delete removes the secret of an account
+func (KeyringBackend) Delete(account string) error {
+	err := keyring.Delete(keyringService, account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package secret
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+)
+
+// Backend names
+const (
+	BackendKeyring = "keyring"
+	BackendFile    = "file"
+	BackendEnv     = "env"
+)
+
+// ErrNotFound is returned when a backend has no secret for an account
+var ErrNotFound = errors.New("secret not found")
+
+// Backend stores secrets such as API keys outside the config file
+type Backend interface {
+	// Name returns the backend name used in references
+	Name() string
+	// Get returns the secret stored for an account
+	Get(account string) (string, error)
+	// Set stores the secret for an account
+	Set(account, value string) error
+	// Delete removes the secret of an account
+	Delete(account string) error
+}
+
+// FormatRef builds the reference stored in the config file, e.g. "keyring:marketing"
+func FormatRef(backend, account string) string {
+	return backend + ":" + account
+}
+
+// ParseRef splits a reference into backend name and account
+func ParseRef(ref string) (backend, account string, err error) {
+	backend, account, ok := strings.Cut(ref, ":")
+	if !ok || backend == "" || account == "" {
+		return "", "", i18n.Errorf("invalid secret reference %q", ref)
+	}
+	return backend, account, nil
+}
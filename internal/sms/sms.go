@@ -0,0 +1,118 @@
+package sms
+
+import (
+	"math"
+	"unicode/utf16"
+)
+
+// Encoding is the character encoding a message is sent with
+type Encoding string
+
+const (
+	// GSM7 is the GSM 03.38 default alphabet, used for plain Latin text
+	GSM7 Encoding = "GSM-7"
+	// UCS2 is used as soon as a message contains a character outside GSM-7, e.g. Persian text
+	UCS2 Encoding = "UCS-2"
+)
+
+// Segment sizes, in septets for GSM-7 and UTF-16 code units for UCS-2
+const (
+	gsm7Single = 160
+	gsm7Multi  = 153
+	ucs2Single = 70
+	ucs2Multi  = 67
+)
+
+// gsm7Basic is the GSM 03.38 basic character set
+var gsm7Basic = map[rune]bool{}
+
+// gsm7Extended are the characters sent with an escape, taking two septets
+var gsm7Extended = map[rune]bool{}
+
+func init() {
+	for _, r := range "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+		"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà" {
+		gsm7Basic[r] = true
+	}
+	for _, r := range "^{}\\[~]|€\f" {
+		gsm7Extended[r] = true
+	}
+}
+
+// Info describes how a message is split into parts
+type Info struct {
+	Encoding Encoding `json:"encoding"`
+	// Length is the message length in septets (GSM-7) or UTF-16 code units (UCS-2)
+	Length int `json:"length"`
+	// Segments is the number of parts the message is billed as
+	Segments int `json:"segments"`
+	// PerSegment is the capacity of each part for this message
+	PerSegment int `json:"perSegment"`
+	// Remaining is how much more fits before another part is needed
+	Remaining int `json:"remaining"`
+}
+
+// Count returns the encoding and number of parts of a message
+func Count(text string) Info {
+	info := Info{Encoding: GSM7}
+	for _, r := range text {
+		switch {
+		case gsm7Basic[r]:
+			info.Length++
+		case gsm7Extended[r]:
+			info.Length += 2
+		default:
+			info.Encoding = UCS2
+		}
+	}
+
+	single, multi := gsm7Single, gsm7Multi
+	if info.Encoding == UCS2 {
+		single, multi = ucs2Single, ucs2Multi
+		info.Length = len(utf16.Encode([]rune(text)))
+	}
+
+	switch {
+	case info.Length == 0:
+		info.Segments = 0
+		info.PerSegment = single
+	case info.Length <= single:
+		info.Segments = 1
+		info.PerSegment = single
+	default:
+		info.Segments = int(math.Ceil(float64(info.Length) / float64(multi)))
+		info.PerSegment = multi
+	}
+	info.Remaining = info.Segments*info.PerSegment - info.Length
+	if info.Segments == 0 {
+		info.Remaining = single
+	}
+
+	return info
+}
+
+// Segments returns the number of parts a message is billed as
+func Segments(text string) int {
+	return Count(text).Segments
+}
+
+// EstimateCost returns the cost of sending a message to a number of
+// recipients at a per-part tariff
+func EstimateCost(text string, recipients int, tariff float64) float64 {
+	return float64(Segments(text)*recipients) * tariff
+}
+
+// UnicodeChars returns the characters that force a message into UCS-2, in
+// the order they first appear and without repeats
+func UnicodeChars(text string) []rune {
+	var chars []rune
+	seen := make(map[rune]bool)
+	for _, r := range text {
+		if gsm7Basic[r] || gsm7Extended[r] || seen[r] {
+			continue
+		}
+		seen[r] = true
+		chars = append(chars, r)
+	}
+	return chars
+}
@@ -0,0 +1,58 @@
+package sms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want Info
+	}{
+		{name: "empty", text: "", want: Info{Encoding: GSM7, Length: 0, Segments: 0, PerSegment: 160, Remaining: 160}},
+		{name: "latin", text: "hello", want: Info{Encoding: GSM7, Length: 5, Segments: 1, PerSegment: 160, Remaining: 155}},
+		{name: "full single part", text: strings.Repeat("a", 160), want: Info{Encoding: GSM7, Length: 160, Segments: 1, PerSegment: 160, Remaining: 0}},
+		{name: "two parts", text: strings.Repeat("a", 161), want: Info{Encoding: GSM7, Length: 161, Segments: 2, PerSegment: 153, Remaining: 145}},
+		{name: "extended characters take two septets", text: "{€}", want: Info{Encoding: GSM7, Length: 6, Segments: 1, PerSegment: 160, Remaining: 154}},
+		{name: "persian", text: "سلام", want: Info{Encoding: UCS2, Length: 4, Segments: 1, PerSegment: 70, Remaining: 66}},
+		{name: "persian in two parts", text: strings.Repeat("س", 71), want: Info{Encoding: UCS2, Length: 71, Segments: 2, PerSegment: 67, Remaining: 63}},
+		{name: "one persian letter switches latin text", text: strings.Repeat("a", 80) + "س", want: Info{Encoding: UCS2, Length: 81, Segments: 2, PerSegment: 67, Remaining: 53}},
+		{name: "emoji takes two code units", text: "😀", want: Info{Encoding: UCS2, Length: 2, Segments: 1, PerSegment: 70, Remaining: 68}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Count(tt.text); got != tt.want {
+				t.Errorf("Count(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	tests := []struct {
+		text       string
+		recipients int
+		tariff     float64
+		want       float64
+	}{
+		{"hello", 3, 1.5, 4.5},
+		{strings.Repeat("س", 71), 2, 1, 4},
+		{"", 10, 1, 0},
+	}
+
+	for _, tt := range tests {
+		if got := EstimateCost(tt.text, tt.recipients, tt.tariff); got != tt.want {
+			t.Errorf("EstimateCost(%q, %d, %v) = %v, want %v", tt.text, tt.recipients, tt.tariff, got, tt.want)
+		}
+	}
+}
+
+func TestUnicodeChars(t *testing.T) {
+	// Each character is listed once, in the order it first appears
+	if got, want := string(UnicodeChars("Hi سلام س!")), "سلام"; got != want {
+		t.Errorf("UnicodeChars() = %q, want %q", got, want)
+	}
+}
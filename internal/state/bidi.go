@@ -0,0 +1,39 @@
+package state
+
+// bidiKey stores whether the UI reorders right-to-left text, per terminal
+const bidiKey = "ui.bidi"
+
+// Bidi returns whether right-to-left text was last chosen to be reordered in
+// a terminal, and whether a choice was stored at all
+func Bidi(terminal string) (enabled, ok bool) {
+	s, err := Load()
+	if err != nil {
+		return false, false
+	}
+
+	terminals := make(map[string]bool)
+	if _, err := s.Get(bidiKey, &terminals); err != nil {
+		return false, false
+	}
+	enabled, ok = terminals[terminal]
+	return enabled, ok
+}
+
+// SetBidi remembers whether right-to-left text is reordered in a terminal
+func SetBidi(terminal string, enabled bool) error {
+	s, err := Load()
+	if err != nil {
+		return err
+	}
+
+	terminals := make(map[string]bool)
+	if _, err := s.Get(bidiKey, &terminals); err != nil {
+		return err
+	}
+	terminals[terminal] = enabled
+
+	if err := s.Set(bidiKey, terminals); err != nil {
+		return err
+	}
+	return s.Save()
+}
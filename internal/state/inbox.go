@@ -0,0 +1,47 @@
+package state
+
+// inboxReadKey stores when each conversation was last read, per profile
+const inboxReadKey = "inbox.read"
+
+// ReadMarks returns when the conversations of a profile were last read, as
+// unix times keyed by mobile number
+func ReadMarks(profile string) map[string]int64 {
+	marks := make(map[string]int64)
+
+	s, err := Load()
+	if err != nil {
+		return marks
+	}
+
+	profiles := make(map[string]map[string]int64)
+	if _, err := s.Get(inboxReadKey, &profiles); err != nil {
+		return marks
+	}
+	for mobile, at := range profiles[profile] {
+		marks[mobile] = at
+	}
+	return marks
+}
+
+// MarkRead remembers that the conversation of a profile with mobile was read
+// up to the given unix time
+func MarkRead(profile, mobile string, at int64) error {
+	s, err := Load()
+	if err != nil {
+		return err
+	}
+
+	profiles := make(map[string]map[string]int64)
+	if _, err := s.Get(inboxReadKey, &profiles); err != nil {
+		return err
+	}
+	if profiles[profile] == nil {
+		profiles[profile] = make(map[string]int64)
+	}
+	profiles[profile][mobile] = at
+
+	if err := s.Set(inboxReadKey, profiles); err != nil {
+		return err
+	}
+	return s.Save()
+}
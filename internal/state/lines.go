@@ -0,0 +1,37 @@
+package state
+
+// lastLinesKey stores the line used most recently, per profile
+const lastLinesKey = "lines.last_used"
+
+// LastLine returns the line number used most recently with a profile, or ""
+func LastLine(profile string) string {
+	s, err := Load()
+	if err != nil {
+		return ""
+	}
+
+	lines := make(map[string]string)
+	if _, err := s.Get(lastLinesKey, &lines); err != nil {
+		return ""
+	}
+	return lines[profile]
+}
+
+// SetLastLine remembers the line number used with a profile
+func SetLastLine(profile, line string) error {
+	s, err := Load()
+	if err != nil {
+		return err
+	}
+
+	lines := make(map[string]string)
+	if _, err := s.Get(lastLinesKey, &lines); err != nil {
+		return err
+	}
+	lines[profile] = line
+
+	if err := s.Set(lastLinesKey, lines); err != nil {
+		return err
+	}
+	return s.Save()
+}
@@ -0,0 +1,90 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+)
+
+const (
+	// stateFileName is the name of the local state file
+	stateFileName = "state.json"
+	// defaultFilePerms are the default permissions for the state file
+	defaultFilePerms = 0600
+)
+
+// State holds small values that must survive between runs, such as sync cursors
+type State struct {
+	path   string
+	values map[string]json.RawMessage
+}
+
+// Load reads the state file from the configuration directory
+func Load() (*State, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &State{
+		path:   filepath.Join(dir, stateFileName),
+		values: make(map[string]json.RawMessage),
+	}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("failed to read state file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &s.values); err != nil {
+		return nil, i18n.Errorf("failed to unmarshal state file: %w", err)
+	}
+
+	return s, nil
+}
+
+// Get decodes the value stored under key into v and reports whether it exists
+func (s *State) Get(key string, v interface{}) (bool, error) {
+	raw, ok := s.values[key]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return false, i18n.Errorf("failed to decode state %q: %w", key, err)
+	}
+	return true, nil
+}
+
+// Set stores v under key; call Save to persist it
+func (s *State) Set(key string, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return i18n.Errorf("failed to encode state %q: %w", key, err)
+	}
+	s.values[key] = raw
+	return nil
+}
+
+// Save writes the state file to disk
+func (s *State) Save() error {
+	if config.ReadOnly() {
+		return config.ErrReadOnly
+	}
+
+	data, err := json.MarshalIndent(s.values, "", "  ")
+	if err != nil {
+		return i18n.Errorf("failed to marshal state: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, defaultFilePerms); err != nil {
+		return i18n.Errorf("failed to write state file: %w", err)
+	}
+
+	return nil
+}
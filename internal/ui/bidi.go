@@ -0,0 +1,371 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/state"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// bidiEnabled is set when the UI reorders right-to-left text itself. It is
+// off by default because many terminals (GNOME Terminal, Konsole, Apple
+// Terminal, mlterm) already apply the bidi algorithm, and reordering the text
+// twice garbles it. Terminals that show Persian reversed opt in with
+// SMSIR_BIDI=1 or with F2, which is remembered for the terminal.
+var bidiEnabled bool
+
+// LoadBidi decides whether right-to-left text is reordered: SMSIR_BIDI when
+// it is set, otherwise the choice last made with F2 in this terminal
+func LoadBidi() {
+	if enabled, ok := bidiFromEnv(); ok {
+		bidiEnabled = enabled
+		return
+	}
+	bidiEnabled, _ = state.Bidi(bidiTerminal())
+}
+
+// bidiFromEnv reads the SMSIR_BIDI opt-in, reporting whether it is set
+func bidiFromEnv() (enabled, ok bool) {
+	switch strings.ToLower(os.Getenv("SMSIR_BIDI")) {
+	case "1", "true", "yes", "on":
+		return true, true
+	case "0", "false", "no", "off":
+		return false, true
+	}
+	return false, false
+}
+
+// bidiTerminal names the terminal the F2 choice is remembered for
+func bidiTerminal() string {
+	if program := os.Getenv("TERM_PROGRAM"); program != "" {
+		return program
+	}
+	if term := os.Getenv("TERM"); term != "" {
+		return term
+	}
+	return "unknown"
+}
+
+// SetBidi turns reordering of right-to-left text on or off
+func SetBidi(enabled bool) {
+	bidiEnabled = enabled
+}
+
+// BidiEnabled reports whether right-to-left text is reordered for display
+func BidiEnabled() bool {
+	return bidiEnabled
+}
+
+// toggleBidi flips reordering of right-to-left text for the F2 key and
+// remembers the choice for this terminal
+func toggleBidi() tea.Cmd {
+	bidiEnabled = !bidiEnabled
+
+	terminal, enabled := bidiTerminal(), bidiEnabled
+	return func() tea.Msg {
+		_ = state.SetBidi(terminal, enabled)
+		return nil
+	}
+}
+
+// bidiLine puts each line of short UI text, such as a title or a table row,
+// in visual order when bidi rendering is on. Unlike renderBidi it neither
+// wraps nor aligns, so layouts built from the text keep their widths.
+func bidiLine(text string) string {
+	if !bidiEnabled || !hasRTL(text) {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = visualOrder(line, paragraphRTL(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderBidi wraps text to width and, when bidi rendering is on, puts every
+// wrapped line in visual order and right-aligns right-to-left paragraphs.
+// Lines are wrapped before they are reordered, as the algorithm requires, so
+// the result can be placed in a lipgloss box of the same width unchanged.
+func renderBidi(text string, width int) string {
+	if !bidiEnabled || !hasRTL(text) {
+		return text
+	}
+
+	var out []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		rtl := paragraphRTL(paragraph)
+		wrapped := lipgloss.NewStyle().Width(width).Render(paragraph)
+		for _, line := range strings.Split(wrapped, "\n") {
+			line = visualOrder(strings.TrimRight(line, " "), rtl)
+			if rtl {
+				line = lipgloss.PlaceHorizontal(width, lipgloss.Right, line)
+			}
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// hasRTL reports whether text contains right-to-left characters
+func hasRTL(text string) bool {
+	for _, r := range text {
+		if c := bidiClass(r); c == bidi.R || c == bidi.AL {
+			return true
+		}
+	}
+	return false
+}
+
+// paragraphRTL returns the paragraph direction: that of its first strong character (rules P2, P3)
+func paragraphRTL(text string) bool {
+	for _, r := range text {
+		switch bidiClass(r) {
+		case bidi.L:
+			return false
+		case bidi.R, bidi.AL:
+			return true
+		}
+	}
+	return false
+}
+
+// bidiClass returns the bidirectional class of a rune
+func bidiClass(r rune) bidi.Class {
+	props, _ := bidi.LookupRune(r)
+	return props.Class()
+}
+
+// mirrored holds the brackets that are mirrored in right-to-left runs (rule L4)
+var mirrored = map[rune]rune{
+	'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{',
+	'<': '>', '>': '<', '«': '»', '»': '«',
+}
+
+// visualOrder reorders one line with the implicit rules of the Unicode
+// Bidirectional Algorithm (UAX #9). Explicit embeddings and isolates are
+// rare in messages and treated as neutral.
+func visualOrder(line string, rtl bool) string {
+	runes := []rune(line)
+	if len(runes) == 0 {
+		return line
+	}
+
+	base := 0
+	sos := bidi.L
+	if rtl {
+		base, sos = 1, bidi.R
+	}
+
+	types := make([]bidi.Class, len(runes))
+	for i, r := range runes {
+		types[i] = bidiClass(r)
+		if types[i] >= bidi.Control {
+			types[i] = bidi.BN
+		}
+	}
+
+	resolveWeak(types, sos)
+	resolveNeutral(types, sos)
+
+	// I1, I2: embedding levels from the resolved types
+	levels := make([]int, len(runes))
+	for i, t := range types {
+		levels[i] = base
+		switch {
+		case base == 0 && t == bidi.R:
+			levels[i] = 1
+		case base == 0 && (t == bidi.EN || t == bidi.AN):
+			levels[i] = 2
+		case base == 1 && (t == bidi.L || t == bidi.EN || t == bidi.AN):
+			levels[i] = 2
+		}
+	}
+
+	// L1: trailing whitespace takes the paragraph level
+	for i := len(runes) - 1; i >= 0 && unicode.IsSpace(runes[i]); i-- {
+		levels[i] = base
+	}
+
+	// Combining marks stay after their base character when a run is reversed
+	type cluster struct {
+		runes []rune
+		level int
+	}
+	var clusters []cluster
+	for i, r := range runes {
+		if len(clusters) > 0 && unicode.In(r, unicode.Mn, unicode.Me) {
+			clusters[len(clusters)-1].runes = append(clusters[len(clusters)-1].runes, r)
+			continue
+		}
+		if levels[i]%2 == 1 {
+			if m, ok := mirrored[r]; ok {
+				r = m
+			}
+		}
+		clusters = append(clusters, cluster{runes: []rune{r}, level: levels[i]})
+	}
+
+	// L2: reverse every sequence at or above each odd level, highest first
+	highest, lowestOdd := 0, 3
+	for _, c := range clusters {
+		highest = max(highest, c.level)
+		if c.level%2 == 1 {
+			lowestOdd = min(lowestOdd, c.level)
+		}
+	}
+	for level := highest; level >= lowestOdd; level-- {
+		for i := 0; i < len(clusters); {
+			if clusters[i].level < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(clusters) && clusters[j].level >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				clusters[a], clusters[b] = clusters[b], clusters[a]
+			}
+			i = j
+		}
+	}
+
+	var b strings.Builder
+	for _, c := range clusters {
+		b.WriteString(string(c.runes))
+	}
+	return b.String()
+}
+
+// resolveWeak applies the weak type rules W1 to W7
+func resolveWeak(types []bidi.Class, sos bidi.Class) {
+	// W1: a nonspacing mark takes the type of the character before it
+	for i, t := range types {
+		if t == bidi.NSM {
+			types[i] = sos
+			if i > 0 {
+				types[i] = types[i-1]
+			}
+		}
+	}
+
+	// W2: European numbers after an Arabic letter are Arabic numbers; W3: AL is R
+	lastStrong := sos
+	for i, t := range types {
+		switch t {
+		case bidi.L, bidi.R, bidi.AL:
+			lastStrong = t
+		case bidi.EN:
+			if lastStrong == bidi.AL {
+				types[i] = bidi.AN
+			}
+		}
+	}
+	for i, t := range types {
+		if t == bidi.AL {
+			types[i] = bidi.R
+		}
+	}
+
+	// W4: a single separator between two numbers of the same type joins them
+	for i := 1; i+1 < len(types); i++ {
+		prev, next := types[i-1], types[i+1]
+		switch {
+		case types[i] == bidi.ES && prev == bidi.EN && next == bidi.EN:
+			types[i] = bidi.EN
+		case types[i] == bidi.CS && prev == next && (prev == bidi.EN || prev == bidi.AN):
+			types[i] = prev
+		}
+	}
+
+	// W5: terminators such as % next to a European number become part of it
+	for i := 0; i < len(types); {
+		if types[i] != bidi.ET {
+			i++
+			continue
+		}
+		j := i
+		for j < len(types) && types[j] == bidi.ET {
+			j++
+		}
+		if (i > 0 && types[i-1] == bidi.EN) || (j < len(types) && types[j] == bidi.EN) {
+			for k := i; k < j; k++ {
+				types[k] = bidi.EN
+			}
+		}
+		i = j
+	}
+
+	// W6: remaining separators and terminators are neutral
+	for i, t := range types {
+		if t == bidi.ES || t == bidi.ET || t == bidi.CS {
+			types[i] = bidi.ON
+		}
+	}
+
+	// W7: European numbers in left-to-right context are L
+	lastStrong = sos
+	for i, t := range types {
+		switch t {
+		case bidi.L, bidi.R:
+			lastStrong = t
+		case bidi.EN:
+			if lastStrong == bidi.L {
+				types[i] = bidi.L
+			}
+		}
+	}
+}
+
+// resolveNeutral applies rules N1 and N2: neutrals between characters of the
+// same direction take that direction, all others the paragraph direction
+func resolveNeutral(types []bidi.Class, sos bidi.Class) {
+	// Numbers count as right-to-left for the surrounding neutrals
+	direction := func(t bidi.Class) bidi.Class {
+		if t == bidi.EN || t == bidi.AN {
+			return bidi.R
+		}
+		return t
+	}
+
+	for i := 0; i < len(types); {
+		if !isNeutral(types[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(types) && isNeutral(types[j]) {
+			j++
+		}
+
+		before, after := sos, sos
+		if i > 0 {
+			before = direction(types[i-1])
+		}
+		if j < len(types) {
+			after = direction(types[j])
+		}
+		resolved := sos
+		if before == after {
+			resolved = before
+		}
+		for k := i; k < j; k++ {
+			types[k] = resolved
+		}
+		i = j
+	}
+}
+
+// isNeutral reports whether a resolved type is neutral for rules N1 and N2
+func isNeutral(t bidi.Class) bool {
+	switch t {
+	case bidi.B, bidi.S, bidi.WS, bidi.ON, bidi.BN:
+		return true
+	}
+	return false
+}
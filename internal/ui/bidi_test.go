@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/unicode/bidi"
+)
+
+func TestVisualOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		rtl  bool
+		want string
+	}{
+		{name: "empty", line: "", rtl: true, want: ""},
+		{name: "left-to-right text", line: "hello world", rtl: false, want: "hello world"},
+		{name: "right-to-left text", line: "سلام دنیا", rtl: true, want: "ایند مالس"},
+		{name: "latin word in persian", line: "سلام world", rtl: true, want: "world مالس"},
+		{name: "persian word in latin", line: "abc سلام def", rtl: false, want: "abc مالس def"},
+		{name: "latin digits keep their order", line: "کد 1234", rtl: true, want: "1234 دک"},
+		{name: "persian digits keep their order", line: "کد ۱۲۳۴", rtl: true, want: "۱۲۳۴ دک"},
+		{name: "brackets are mirrored", line: "(سلام)", rtl: true, want: "(مالس)"},
+		{name: "trailing spaces stay at the end", line: "سلام  ", rtl: false, want: "مالس  "},
+		{name: "combining marks stay after their letter", line: "سَلام", rtl: true, want: "مالسَ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := visualOrder(tt.line, tt.rtl); got != tt.want {
+				t.Errorf("visualOrder(%q, %v) = %q, want %q", tt.line, tt.rtl, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveWeak(t *testing.T) {
+	tests := []struct {
+		name  string
+		types []bidi.Class
+		sos   bidi.Class
+		want  []bidi.Class
+	}{
+		{name: "W1 mark after a letter", types: []bidi.Class{bidi.R, bidi.NSM}, sos: bidi.L, want: []bidi.Class{bidi.R, bidi.R}},
+		{name: "W1 mark at the start", types: []bidi.Class{bidi.NSM, bidi.L}, sos: bidi.R, want: []bidi.Class{bidi.R, bidi.L}},
+		{name: "W2 number after an arabic letter", types: []bidi.Class{bidi.AL, bidi.EN}, sos: bidi.L, want: []bidi.Class{bidi.R, bidi.AN}},
+		{name: "W4 plus between european numbers", types: []bidi.Class{bidi.EN, bidi.ES, bidi.EN}, sos: bidi.R, want: []bidi.Class{bidi.EN, bidi.EN, bidi.EN}},
+		{name: "W4 comma between arabic numbers", types: []bidi.Class{bidi.AN, bidi.CS, bidi.AN}, sos: bidi.R, want: []bidi.Class{bidi.AN, bidi.AN, bidi.AN}},
+		{name: "W4 separator between different numbers", types: []bidi.Class{bidi.EN, bidi.CS, bidi.AN}, sos: bidi.R, want: []bidi.Class{bidi.EN, bidi.ON, bidi.AN}},
+		{name: "W5 terminators before a number", types: []bidi.Class{bidi.ET, bidi.ET, bidi.EN}, sos: bidi.R, want: []bidi.Class{bidi.EN, bidi.EN, bidi.EN}},
+		{name: "W6 lone terminator", types: []bidi.Class{bidi.R, bidi.ET, bidi.R}, sos: bidi.R, want: []bidi.Class{bidi.R, bidi.ON, bidi.R}},
+		{name: "W7 number after a latin letter", types: []bidi.Class{bidi.L, bidi.WS, bidi.EN}, sos: bidi.R, want: []bidi.Class{bidi.L, bidi.WS, bidi.L}},
+		{name: "W7 number in a left-to-right paragraph", types: []bidi.Class{bidi.EN}, sos: bidi.L, want: []bidi.Class{bidi.L}},
+		{name: "W7 number after a right-to-left letter", types: []bidi.Class{bidi.R, bidi.EN}, sos: bidi.L, want: []bidi.Class{bidi.R, bidi.EN}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			types := append([]bidi.Class(nil), tt.types...)
+			resolveWeak(types, tt.sos)
+			if !reflect.DeepEqual(types, tt.want) {
+				t.Errorf("resolveWeak(%v, %v) = %v, want %v", tt.types, tt.sos, types, tt.want)
+			}
+		})
+	}
+}
+
+func TestParagraphRTL(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"سلام world", true},
+		{"hello سلام", false},
+		{"123 سلام", true},
+		{"123", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := paragraphRTL(tt.text); got != tt.want {
+			t.Errorf("paragraphRTL(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
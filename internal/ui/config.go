@@ -1,11 +1,14 @@
 package ui
 
 import (
-	"fmt"
 	"strings"
 
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
 	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/state"
 	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -20,11 +23,58 @@ type ConfigModel struct {
 	width      int
 	height     int
 	step       int // 0: api key, 1: line number, 2: confirm
+
+	// linePicker lists the lines of the account the entered API key belongs to
+	linePicker LinePicker
+
+	// checking is set while the entered values are verified against the account
+	checking bool
+	checks   []api.CheckResult
+
+	// visited is the furthest step reached; those steps can be jumped back to
+	visited int
+	// stepErr tells why the current step could not be completed
+	stepErr string
+	// confirmingQuit is set while asking whether to throw away the entered API key
+	confirmingQuit bool
+
+	// profile is the active profile as stored, which the entered values are
+	// saved into; loadErr is set when it could not be loaded
+	profile *config.Config
+	loadErr error
+	// lastLine is the line last sent from, preselected in the line picker
+	lastLine string
+
+	// err is set when the configuration could not be saved
+	err error
+}
+
+// profileLoadedMsg carries the profile loaded for a ConfigModel
+type profileLoadedMsg struct {
+	cfg      *config.Config
+	lastLine string
+	err      error
+}
+
+// configCheckedMsg carries the result of the online checks run before saving
+type configCheckedMsg struct {
+	cfg     *config.Config
+	results []api.CheckResult
 }
 
-// Init initializes the config model
+// Init starts loading the profile the configuration is saved into
 func (m ConfigModel) Init() tea.Cmd {
-	return nil
+	return loadProfileForEdit
+}
+
+// loadProfileForEdit loads the active profile without the project file and
+// SMSIR_* values, so those are not saved as if the user had entered them
+func loadProfileForEdit() tea.Msg {
+	cfg, err := config.LoadProfileForEdit()
+	if err != nil {
+		return profileLoadedMsg{err: err}
+	}
+	return profileLoadedMsg{cfg: cfg, lastLine: state.LastLine(cfg.Profile)}
 }
 
 // Update handles messages
@@ -35,81 +85,116 @@ func (m ConfigModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case spinner.TickMsg, linesLoadedMsg:
+		var cmd tea.Cmd
+		m.linePicker, cmd = m.linePicker.Update(msg)
+		return m, cmd
+
+	case profileLoadedMsg:
+		m.profile, m.lastLine, m.loadErr = msg.cfg, msg.lastLine, msg.err
+		return m, nil
+
+	case configCheckedMsg:
+		m.checking = false
+		m.checks = msg.results
+		if !api.ChecksPassed(msg.results) {
+			// Stay on the confirm step so the failures can be read
+			return m, nil
+		}
+		if err := msg.cfg.SaveConfig(); err != nil {
+			// The launcher shows the error in the selector
+			m.err = err
+			m.quitting = true
+			return m, tea.Quit
+		}
+		m.completed = true
+		return m, tea.Quit
+
 	case tea.KeyMsg:
-		// Handle paste first
-		if msg.Type == tea.KeyCtrlV {
-			clipboardText, err := clipboard.ReadAll()
-			if err == nil && clipboardText != "" {
-				// Clean the clipboard text
-				cleanText := strings.TrimSpace(strings.ReplaceAll(clipboardText, "\n", ""))
-				cleanText = strings.ReplaceAll(cleanText, "\r", "")
-
-				if m.step == 0 {
-					m.apiKey = cleanText
-				} else if m.step == 1 {
-					m.lineNumber = cleanText
-				}
+		if m.checking {
+			// Only cancelling is possible while the checks run
+			if msg.String() == "ctrl+c" {
+				m.quitting = true
+				return m, tea.Quit
 			}
 			return m, nil
 		}
 
+		if m.confirmingQuit {
+			return m.answerQuit(msg)
+		}
+
+		// Handle paste first
+		if msg.Type == tea.KeyCtrlV {
+			return m.paste(), nil
+		}
+
 		switch msg.String() {
-		case "ctrl+c", "q", "esc":
-			m.quitting = true
-			return m, tea.Quit
+		case "ctrl+c":
+			return m.quit()
 
-		case "ctrl+v":
-			// Alternative paste method
-			clipboardText, err := clipboard.ReadAll()
-			if err == nil && clipboardText != "" {
-				cleanText := strings.TrimSpace(strings.ReplaceAll(clipboardText, "\n", ""))
-				cleanText = strings.ReplaceAll(cleanText, "\r", "")
-
-				if m.step == 0 {
-					m.apiKey = cleanText
-				} else if m.step == 1 {
-					m.lineNumber = cleanText
-				}
+		case "esc":
+			if m.step == 0 {
+				return m.quit()
+			}
+			return m.jumpTo(m.step - 1), nil
+
+		case "shift+tab":
+			if m.step > 0 {
+				return m.jumpTo(m.step - 1), nil
 			}
 			return m, nil
 
+		case "q":
+			// q is text in the API key
+			if m.step != 0 {
+				return m.quit()
+			}
+
+		case "ctrl+v":
+			// Alternative paste method
+			return m.paste(), nil
+
+		case "f2":
+			return m, toggleBidi()
+
 		case "enter":
 			if m.step == 2 {
-				// Save configuration
-				cfg := &config.Config{
-					APIKey:     m.apiKey,
-					LineNumber: m.lineNumber,
-					BaseURL:    "https://api.sms.ir/v1",
+				if m.profile == nil {
+					// Still loading, or nothing to save into
+					return m, nil
 				}
-				if err := cfg.SaveConfig(); err != nil {
-					// Error will be handled by launcher
-					m.completed = false
-					m.quitting = true
-					return m, tea.Quit
-				}
-				m.completed = true
-				return m, tea.Quit
+				// Verify against the account before saving, so a mistyped key is never saved
+				m.checking = true
+				m.checks = nil
+				return m, m.checkConfig()
 			}
+			return m.next()
+		}
 
-			// Only advance if we have valid input
-			if m.step == 0 && m.apiKey != "" {
-				m.step++
-			} else if m.step == 1 && m.lineNumber != "" {
-				m.step++
+		// Any step can be edited again from the confirm step
+		if m.step == 2 {
+			if step, ok := stepKey(msg, 2); ok {
+				return m.jumpTo(step), nil
 			}
 			return m, nil
+		}
+
+		m.stepErr = ""
 
+		if m.step == 1 {
+			var cmd tea.Cmd
+			m.linePicker, cmd = m.linePicker.Update(msg)
+			return m, cmd
+		}
+
+		switch msg.String() {
 		case "backspace":
 			if m.step == 0 && len(m.apiKey) > 0 {
 				runes := []rune(m.apiKey)
 				if len(runes) > 0 {
 					m.apiKey = string(runes[:len(runes)-1])
 				}
-			} else if m.step == 1 && len(m.lineNumber) > 0 {
-				runes := []rune(m.lineNumber)
-				if len(runes) > 0 {
-					m.lineNumber = string(runes[:len(runes)-1])
-				}
 			}
 			return m, nil
 
@@ -117,12 +202,8 @@ func (m ConfigModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Accept printable runes, including multi-rune inserts (e.g., pastes on some terminals)
 			if msg.Type == tea.KeyRunes {
 				text := msg.String()
-				if text != "" {
-					if m.step == 0 {
-						m.apiKey += text
-					} else if m.step == 1 {
-						m.lineNumber += text
-					}
+				if text != "" && m.step == 0 {
+					m.apiKey += text
 				}
 				return m, nil
 			}
@@ -135,10 +216,111 @@ func (m ConfigModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 }
 
+// paste inserts the clipboard text into the current step's input
+func (m ConfigModel) paste() ConfigModel {
+	clipboardText, err := clipboard.ReadAll()
+	if err != nil || clipboardText == "" {
+		return m
+	}
+
+	// Clean the clipboard text
+	cleanText := strings.TrimSpace(strings.ReplaceAll(clipboardText, "\n", ""))
+	cleanText = strings.ReplaceAll(cleanText, "\r", "")
+
+	if m.step == 0 {
+		m.apiKey = cleanText
+	} else if m.step == 1 {
+		m.linePicker.SetInput(cleanText)
+	}
+	m.stepErr = ""
+	return m
+}
+
+// next moves on from the current step when its input is valid
+func (m ConfigModel) next() (tea.Model, tea.Cmd) {
+	switch m.step {
+	case 0:
+		if m.apiKey == "" {
+			m.stepErr = i18n.T("api key is required")
+			return m, nil
+		}
+		if m.profile == nil {
+			// The lines are listed with the loaded profile's settings
+			return m, nil
+		}
+		// The lines depend on the key, so they are fetched again after every change
+		m = m.jumpTo(1)
+		m.linePicker = m.newLinePicker()
+		return m, m.linePicker.Init()
+
+	case 1:
+		if m.linePicker.Loading() {
+			return m, nil
+		}
+		m.lineNumber = m.linePicker.Value()
+		// A line alias of the profile is as good as a number
+		if _, err := m.profile.ResolveLine(m.lineNumber); err != nil {
+			m.stepErr = err.Error()
+			return m, nil
+		}
+		return m.jumpTo(2), nil
+	}
+	return m, nil
+}
+
+// jumpTo shows a step; results of an earlier check no longer apply
+func (m ConfigModel) jumpTo(step int) ConfigModel {
+	m.step = step
+	m.visited = max(m.visited, step)
+	m.stepErr = ""
+	m.checks = nil
+	return m
+}
+
+// quit leaves the wizard, asking first when an entered API key would be lost
+func (m ConfigModel) quit() (tea.Model, tea.Cmd) {
+	if m.apiKey != "" {
+		m.confirmingQuit = true
+		return m, nil
+	}
+	m.quitting = true
+	return m, tea.Quit
+}
+
+// answerQuit handles the answer to the question asked by quit
+func (m ConfigModel) answerQuit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y", "ctrl+c":
+		m.quitting = true
+		return m, tea.Quit
+	case "n", "N", "esc":
+		m.confirmingQuit = false
+	}
+	return m, nil
+}
+
+// newLinePicker creates a picker listing the lines of the entered API key's account
+func (m ConfigModel) newLinePicker() LinePicker {
+	withKey := *m.profile
+	withKey.APIKey = m.apiKey
+	return NewLinePicker(api.NewClient(&withKey), m.profile, m.lastLine)
+}
+
+// checkConfig runs the online checks on the entered values
+func (m ConfigModel) checkConfig() tea.Cmd {
+	// Save into the active profile, keeping its other settings
+	cfg := *m.profile
+	cfg.APIKey = m.apiKey
+	cfg.LineNumber = m.lineNumber
+	return func() tea.Msg {
+		return configCheckedMsg{cfg: &cfg, results: api.NewClient(&cfg).CheckConfig()}
+	}
+}
+
 // View renders the config interface
 func (m ConfigModel) View() string {
 	if m.quitting && !m.completed {
-		return "Configuration cancelled.\n"
+		return i18n.T("Configuration cancelled.\n")
 	}
 
 	if m.completed {
@@ -162,6 +344,11 @@ func (m ConfigModel) View() string {
 	s.WriteString(content)
 	s.WriteString("\n\n")
 
+	if m.confirmingQuit {
+		s.WriteString(renderQuitPrompt(i18n.T("Discard the entered API key?")))
+		return s.String()
+	}
+
 	// Instructions
 	instructions := m.renderInstructions()
 	s.WriteString(instructions)
@@ -176,28 +363,14 @@ func (m ConfigModel) renderHeader() string {
 		Foreground(lipgloss.Color("#f7bd60")).
 		Align(lipgloss.Center)
 
-	title := "🔧 Configuration Setup"
-	return titleStyle.Render(title)
+	title := i18n.T("🔧 Configuration Setup")
+	return titleStyle.Render(bidiLine(title))
 }
 
 // renderProgress renders progress indicator
 func (m ConfigModel) renderProgress() string {
-	steps := []string{"API Key", "Line Number", "Confirm"}
-
-	var progress []string
-	for i, step := range steps {
-		if i <= m.step {
-			progress = append(progress, fmt.Sprintf("✓ %s", step))
-		} else {
-			progress = append(progress, fmt.Sprintf("○ %s", step))
-		}
-	}
-
-	progressStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#9CA3AF")).
-		Align(lipgloss.Center)
-
-	return progressStyle.Render(strings.Join(progress, " → "))
+	steps := []string{i18n.T("API Key"), i18n.T("Line Number"), i18n.T("Confirm")}
+	return renderSteps(steps, m.step, m.visited)
 }
 
 // renderContent renders the main content
@@ -218,6 +391,12 @@ func (m ConfigModel) renderContent() string {
 	case 2:
 		content = m.renderConfirmStep()
 	}
+	if m.stepErr != "" {
+		content += "\n\n" + renderStepError(m.stepErr)
+	}
+	if m.loadErr != nil {
+		content += "\n\n" + renderStepError(i18n.Sprintf("Could not load the configuration: %v", m.loadErr))
+	}
 
 	return boxStyle.Render(content)
 }
@@ -236,40 +415,29 @@ func (m ConfigModel) renderAPIKeyStep() string {
 		Foreground(lipgloss.Color("#9CA3AF")).
 		Italic(true)
 
-	title := "Enter your SMS.ir API Key:"
+	title := i18n.T("Enter your SMS.ir API Key:")
 	var input string
 	if m.apiKey == "" {
-		input = placeholderStyle.Render("Type here or press Ctrl+V to paste...")
+		input = placeholderStyle.Render(bidiLine(i18n.T("Type here or press Ctrl+V to paste...")))
 	} else {
 		input = inputStyle.Render(m.apiKey)
 	}
 
-	return titleStyle.Render(title) + "\n\n" + input
+	return titleStyle.Render(bidiLine(title)) + "\n\n" + input
 }
 
-// renderLineNumberStep renders the line number input step
+// renderLineNumberStep renders the line picker step
 func (m ConfigModel) renderLineNumberStep() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("#f7bd60"))
 
-	inputStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff")).
-		Bold(true)
-
-	placeholderStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#9CA3AF")).
-		Italic(true)
-
-	title := "Enter your Line Number:"
-	var input string
-	if m.lineNumber == "" {
-		input = placeholderStyle.Render("Type here or press Ctrl+V to paste...")
-	} else {
-		input = inputStyle.Render(m.lineNumber)
+	title := i18n.T("Choose your Line Number:")
+	if m.linePicker.Manual() {
+		title = i18n.T("Enter your Line Number:")
 	}
 
-	return titleStyle.Render(title) + "\n\n" + input
+	return titleStyle.Render(bidiLine(title)) + "\n\n" + m.linePicker.View()
 }
 
 // renderConfirmStep renders the confirmation step
@@ -281,13 +449,42 @@ func (m ConfigModel) renderConfirmStep() string {
 	infoStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#ffffff"))
 
-	title := "Confirm Configuration:"
-	apiKey := fmt.Sprintf("API Key: %s", maskString(m.apiKey))
-	lineNumber := fmt.Sprintf("Line Number: %s", m.lineNumber)
+	title := i18n.T("Confirm Configuration:")
+	apiKey := i18n.Sprintf("API Key: %s", maskString(m.apiKey))
+	lineNumber := i18n.Sprintf("Line Number: %s", m.lineNumber)
+
+	content := titleStyle.Render(bidiLine(title)) + "\n\n" +
+		infoStyle.Render(bidiLine(apiKey)) + "\n" +
+		infoStyle.Render(bidiLine(lineNumber))
+
+	if m.checking {
+		mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+		content += "\n\n" + mutedStyle.Render(bidiLine(i18n.T("⏳ Checking the configuration against your account...")))
+	} else if len(m.checks) > 0 {
+		content += "\n\n" + renderChecks(m.checks)
+	}
+
+	return content
+}
 
-	return titleStyle.Render(title) + "\n\n" +
-		infoStyle.Render(apiKey) + "\n" +
-		infoStyle.Render(lineNumber)
+// renderChecks renders the results of the online checks
+func renderChecks(results []api.CheckResult) string {
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ffffff"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#f7bd60"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B"))
+
+	lines := make([]string, len(results))
+	for i, r := range results {
+		switch {
+		case r.OK:
+			lines[i] = okStyle.Render(bidiLine(i18n.Sprintf("✅ %s: %s", i18n.T(r.Name), r.Detail)))
+		case r.Warning:
+			lines[i] = warnStyle.Render(bidiLine(i18n.Sprintf("⚠️  %s: %s", i18n.T(r.Name), r.Detail)))
+		default:
+			lines[i] = errorStyle.Render(bidiLine(i18n.Sprintf("❌ %s: %s", i18n.T(r.Name), r.Detail)))
+		}
+	}
+	return strings.Join(lines, "\n")
 }
 
 // renderInstructions renders instructions
@@ -297,20 +494,38 @@ func (m ConfigModel) renderInstructions() string {
 		Align(lipgloss.Center)
 
 	var instructions []string
-	if m.step < 2 {
+	if m.step == 0 {
+		instructions = []string{
+			i18n.T("Type your information and press Enter to continue"),
+			i18n.T("Press Ctrl+V to paste from clipboard"),
+			i18n.T("Esc or Ctrl+C: cancel"),
+		}
+	} else if m.step == 1 {
+		instructions = []string{
+			i18n.T("Type your information and press Enter to continue"),
+			i18n.T("Esc or Shift+Tab: back"),
+			i18n.T("Press Ctrl+V to paste from clipboard"),
+			i18n.T("Press q or Ctrl+C to cancel"),
+		}
+	} else if m.checking {
+		instructions = []string{i18n.T("Press Ctrl+C to cancel")}
+	} else if len(m.checks) > 0 {
 		instructions = []string{
-			"Type your information and press Enter to continue",
-			"Press Ctrl+V to paste from clipboard",
-			"Press q or Ctrl+C to cancel",
+			i18n.T("Press Enter to check again"),
+			i18n.T("1-2: edit a step"),
+			i18n.T("Esc or Shift+Tab: back"),
+			i18n.T("Press q or Ctrl+C to cancel"),
 		}
 	} else {
 		instructions = []string{
-			"Press Enter to save configuration",
-			"Press q or Ctrl+C to cancel",
+			i18n.T("Press Enter to check and save configuration"),
+			i18n.T("1-2: edit a step"),
+			i18n.T("Esc or Shift+Tab: back"),
+			i18n.T("Press q or Ctrl+C to cancel"),
 		}
 	}
 
-	return instructionStyle.Render(strings.Join(instructions, " • "))
+	return instructionStyle.Render(bidiLine(strings.Join(instructions, " • ")))
 }
 
 // maskString masks sensitive information
@@ -0,0 +1,294 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/contacts"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pickerRows is the number of entries a ContactPicker shows at once
+const pickerRows = 8
+
+// pickerEntry is a group or a single contact in a ContactPicker
+type pickerEntry struct {
+	group   string
+	members []contacts.Contact
+	contact contacts.Contact
+}
+
+// id identifies the entry in the selection
+func (e pickerEntry) id() string {
+	if e.group != "" {
+		return "group:" + strings.ToLower(e.group)
+	}
+	return "contact:" + e.contact.Mobile
+}
+
+// haystack is the text the search query is matched against
+func (e pickerEntry) haystack() string {
+	if e.group != "" {
+		return e.group
+	}
+	return e.contact.Name + " " + e.contact.Mobile + " " + strings.Join(e.contact.Groups, " ")
+}
+
+// ContactPicker lets the user select several local contacts and groups,
+// narrowed down by a fuzzy search query
+type ContactPicker struct {
+	entries  []pickerEntry
+	names    map[string]string
+	query    string
+	cursor   int
+	offset   int
+	selected map[string]bool
+	// order keeps the selected entries in the order they were picked
+	order []string
+}
+
+// NewContactPicker creates a picker over the groups and contacts of a book;
+// a nil book gives an empty picker
+func NewContactPicker(book *contacts.Book) ContactPicker {
+	p := ContactPicker{
+		names:    make(map[string]string),
+		selected: make(map[string]bool),
+	}
+	if book == nil {
+		return p
+	}
+
+	for _, group := range book.Groups() {
+		p.entries = append(p.entries, pickerEntry{group: group, members: book.Group(group)})
+	}
+	for _, c := range book.Contacts() {
+		p.entries = append(p.entries, pickerEntry{contact: c})
+		p.names[c.Mobile] = c.Name
+	}
+	return p
+}
+
+// Empty reports whether there is nothing to pick from
+func (p ContactPicker) Empty() bool {
+	return len(p.entries) == 0
+}
+
+// Update handles keys: typing edits the search query, ↑/↓ move and Space
+// selects. Enter and Tab are left to the parent model.
+func (p ContactPicker) Update(msg tea.Msg) (ContactPicker, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	matches := p.matches()
+	switch keyMsg.String() {
+	case "up":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down":
+		if p.cursor < len(matches)-1 {
+			p.cursor++
+		}
+	case " ":
+		if p.cursor < len(matches) {
+			p.toggle(matches[p.cursor].id())
+		}
+	case "backspace":
+		if runes := []rune(p.query); len(runes) > 0 {
+			p.query = string(runes[:len(runes)-1])
+			p.cursor = 0
+		}
+	default:
+		if keyMsg.Type == tea.KeyRunes {
+			p.query += keyMsg.String()
+			p.cursor = 0
+		}
+	}
+
+	// Keep the cursor within the visible rows
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+pickerRows {
+		p.offset = p.cursor - pickerRows + 1
+	}
+	return p, nil
+}
+
+// toggle selects or deselects an entry
+func (p *ContactPicker) toggle(id string) {
+	if p.selected[id] {
+		delete(p.selected, id)
+		for i, picked := range p.order {
+			if picked == id {
+				p.order = append(p.order[:i], p.order[i+1:]...)
+				break
+			}
+		}
+		return
+	}
+	p.selected[id] = true
+	p.order = append(p.order, id)
+}
+
+// matches returns the entries matching the search query, best matches first
+func (p ContactPicker) matches() []pickerEntry {
+	query := strings.TrimSpace(p.query)
+	if query == "" {
+		return p.entries
+	}
+
+	type match struct {
+		entry pickerEntry
+		score int
+	}
+	var found []match
+	for _, e := range p.entries {
+		if score, ok := fuzzyScore(query, e.haystack()); ok {
+			found = append(found, match{entry: e, score: score})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].score > found[j].score
+	})
+
+	entries := make([]pickerEntry, len(found))
+	for i, f := range found {
+		entries[i] = f.entry
+	}
+	return entries
+}
+
+// fuzzyScore reports whether the letters of query appear in text in order,
+// ignoring case and spaces. Consecutive letters and matches at the start of
+// a word score higher.
+func fuzzyScore(query, text string) (int, bool) {
+	target := []rune(strings.ToLower(text))
+	score := 0
+	pos := 0
+	prev := -2
+	for _, r := range strings.ToLower(query) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		for pos < len(target) && target[pos] != r {
+			pos++
+		}
+		if pos == len(target) {
+			return 0, false
+		}
+		score++
+		if pos == prev+1 {
+			score += 2
+		}
+		if pos == 0 || unicode.IsSpace(target[pos-1]) {
+			score += 3
+		}
+		prev = pos
+		pos++
+	}
+	return score, true
+}
+
+// Count returns the number of selected contacts and groups
+func (p ContactPicker) Count() int {
+	return len(p.order)
+}
+
+// Mobiles returns the numbers of the selected contacts and group members,
+// without duplicates, in the order they were picked
+func (p ContactPicker) Mobiles() []string {
+	byID := make(map[string]pickerEntry, len(p.entries))
+	for _, e := range p.entries {
+		byID[e.id()] = e
+	}
+
+	seen := make(map[string]bool)
+	var mobiles []string
+	add := func(mobile string) {
+		if !seen[mobile] {
+			seen[mobile] = true
+			mobiles = append(mobiles, mobile)
+		}
+	}
+	for _, id := range p.order {
+		e := byID[id]
+		if e.group != "" {
+			for _, c := range e.members {
+				add(c.Mobile)
+			}
+			continue
+		}
+		add(e.contact.Mobile)
+	}
+	return mobiles
+}
+
+// Name returns the contact name of a number, if it is in the book
+func (p ContactPicker) Name(mobile string) string {
+	return p.names[mobile]
+}
+
+// View renders the search query and the matching entries
+func (p ContactPicker) View() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#f7bd60")).Bold(true)
+	inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ffffff")).Bold(true)
+
+	if p.Empty() {
+		return mutedStyle.Render(bidiLine(i18n.T("No contacts yet. Add some with: smsir contacts add <name> <mobile>")))
+	}
+
+	var s strings.Builder
+	s.WriteString("🔍 ")
+	if p.query == "" {
+		s.WriteString(mutedStyle.Italic(true).Render(bidiLine(i18n.T("Type to search contacts and groups..."))))
+	} else {
+		s.WriteString(inputStyle.Render(bidiLine(p.query)))
+	}
+	s.WriteString("\n\n")
+
+	matches := p.matches()
+	if len(matches) == 0 {
+		s.WriteString(mutedStyle.Render(bidiLine(i18n.T("No matching contacts"))))
+		return s.String()
+	}
+
+	end := min(p.offset+pickerRows, len(matches))
+	for i := p.offset; i < end; i++ {
+		e := matches[i]
+
+		check := "[ ]"
+		if p.selected[e.id()] {
+			check = "[x]"
+		}
+
+		var label, note string
+		if e.group != "" {
+			label = "👥 " + e.group
+			note = i18n.Sprintf("%d member(s)", len(e.members))
+		} else {
+			label = e.contact.Name + "  " + e.contact.Mobile
+			note = strings.Join(e.contact.Groups, ", ")
+		}
+		label = bidiLine(label)
+		if note != "" {
+			note = " " + mutedStyle.Render(bidiLine("("+note+")"))
+		}
+
+		if i == p.cursor {
+			s.WriteString(selectedStyle.Render("▶ "+check+" "+label) + note + "\n")
+		} else {
+			s.WriteString("  " + check + " " + label + note + "\n")
+		}
+	}
+	if len(matches) > pickerRows {
+		s.WriteString(mutedStyle.Render(bidiLine(i18n.Sprintf("%d-%d of %d", p.offset+1, end, len(matches)))) + "\n")
+	}
+	return strings.TrimSuffix(s.String(), "\n")
+}
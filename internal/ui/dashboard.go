@@ -1,11 +1,13 @@
 package ui
 
 import (
-	"fmt"
 	"strings"
+	"time"
 
 	"github.com/SaneiyanReza/smsir-cli/internal/api"
 	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/history"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -21,16 +23,75 @@ type Model struct {
 	width    int
 	height   int
 	quitting bool
+
+	// refresh is the auto-refresh interval, 0 when only r refreshes;
+	// nextRefresh is when it happens and now the time of the last tick
+	refresh     time.Duration
+	nextRefresh time.Time
+	now         time.Time
+
+	// samples is the stored credit history of the profile, oldest first
+	samples []history.CreditSample
+
+	activity activity
+	inbox    []api.ReceivedMessage
+	inboxErr error
+
+	// focus is the highlighted panel; detail shows it alone, scrolled down by offset lines
+	focus  panel
+	detail bool
+	offset int
 }
 
+// refreshTickMsg counts down to the next auto-refresh
+type refreshTickMsg time.Time
+
+// creditHistoryMsg carries the credit history after a reading was stored
+type creditHistoryMsg []history.CreditSample
+
+// sparklineWidth is the most bars the credit sparkline has
+const sparklineWidth = 60
+
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
+	return tea.Batch(
+		m.reload(),
+		m.tick(),
+	)
+}
+
+// reload fetches the credit, lines and inbox; the sends are read from the
+// history once the credit reading is stored
+func (m Model) reload() tea.Cmd {
 	return tea.Batch(
 		loadCredit(m.client),
 		loadLines(m.client),
+		loadInbox(m.client),
 	)
 }
 
+// tick schedules the next countdown step; there is none without auto-refresh
+func (m Model) tick() tea.Cmd {
+	if m.refresh <= 0 {
+		return nil
+	}
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return refreshTickMsg(t)
+	})
+}
+
+// recordCredit stores a credit reading and loads the credit history. The
+// history is best effort: without it there is just no sparkline.
+func recordCredit(profile string, credit float64) tea.Cmd {
+	return func() tea.Msg {
+		samples, err := history.RecordCredit(profile, credit)
+		if err != nil {
+			return nil
+		}
+		return creditHistoryMsg(samples)
+	}
+}
+
 // Update handles messages
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -45,16 +106,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.quitting = true
 			return m, tea.Quit
 		case "r":
-			return m, tea.Batch(
-				loadCredit(m.client),
-				loadLines(m.client),
-			)
+			m.nextRefresh = m.now.Add(m.refresh)
+			return m, m.reload()
+		case "f2":
+			return m, toggleBidi()
 		}
-		return m, nil
+		if m.detail {
+			return m.updateDetail(msg)
+		}
+		return m.updateFocus(msg)
+
+	case refreshTickMsg:
+		m.now = time.Time(msg)
+		if m.now.Before(m.nextRefresh) {
+			return m, m.tick()
+		}
+		m.nextRefresh = m.now.Add(m.refresh)
+		return m, tea.Batch(m.reload(), m.tick())
 
 	case creditMsg:
 		m.credit = float64(msg)
 		m.loading = false
+		m.err = nil
+		// The history database is opened by one reader at a time
+		return m, tea.Sequence(
+			recordCredit(m.config.Profile, m.credit),
+			loadActivity(m.config.Profile, m.config.Dashboard.Recent),
+		)
+
+	case creditHistoryMsg:
+		m.samples = msg
+		return m, nil
+
+	case activityMsg:
+		m.activity = activity(msg)
+		m.now = time.Now()
+		return m, nil
+
+	case inboxMsg:
+		m.inbox = msg.messages
+		m.inboxErr = msg.err
 		return m, nil
 
 	case linesMsg:
@@ -72,6 +163,48 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// updateFocus moves the focus between the panels of the grid and opens the
+// focused one. Tab and the left and right arrows go through the panels in
+// order; up and down move to the panel above or below.
+func (m Model) updateFocus(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	cols := panel(m.columns())
+	switch msg.String() {
+	case "tab", "right", "l":
+		m.focus = (m.focus + 1) % panelCount
+	case "shift+tab", "left", "h":
+		m.focus = (m.focus + panelCount - 1) % panelCount
+	case "down", "j":
+		if m.focus+cols < panelCount {
+			m.focus += cols
+		}
+	case "up", "k":
+		if m.focus >= cols {
+			m.focus -= cols
+		}
+	case "enter":
+		m.detail = true
+		m.offset = 0
+	}
+	return m, nil
+}
+
+// updateDetail scrolls the detail view and closes it
+func (m Model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "backspace", "enter":
+		m.detail = false
+	case "down", "j":
+		m.offset = min(m.offset+1, m.maxOffset())
+	case "up", "k":
+		m.offset = max(m.offset-1, 0)
+	case "pgdown", " ":
+		m.offset = min(m.offset+m.detailRows(), m.maxOffset())
+	case "pgup":
+		m.offset = max(m.offset-m.detailRows(), 0)
+	}
+	return m, nil
+}
+
 // View renders the UI
 func (m Model) View() string {
 	if m.quitting {
@@ -99,8 +232,8 @@ func (m Model) View() string {
 
 // renderHeader renders the application header
 func (m Model) renderHeader() string {
-	title := "📱 SMS.ir CLI Dashboard"
-	subtitle := "A simple message can connect worlds with a single command"
+	title := i18n.T("📱 SMS.ir CLI Dashboard")
+	subtitle := i18n.T("A simple message can connect worlds with a single command")
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -112,7 +245,7 @@ func (m Model) renderHeader() string {
 		Align(lipgloss.Center).
 		Italic(true)
 
-	return titleStyle.Render(title) + "\n" + subtitleStyle.Render(subtitle)
+	return titleStyle.Render(bidiLine(title)) + "\n" + subtitleStyle.Render(bidiLine(subtitle))
 }
 
 // renderLoading renders loading state
@@ -121,7 +254,7 @@ func (m Model) renderLoading() string {
 		Foreground(lipgloss.Color("#f7bd60")).
 		Align(lipgloss.Center)
 
-	return loadingStyle.Render("Loading... ⏳")
+	return loadingStyle.Render(bidiLine(i18n.T("Loading... ⏳")))
 }
 
 // renderError renders error state
@@ -132,21 +265,23 @@ func (m Model) renderError() string {
 		Border(lipgloss.RoundedBorder()).
 		Padding(1, 2)
 
-	return errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	return errorStyle.Render(bidiLine(i18n.Sprintf("Error: %v", m.err)))
 }
 
 // renderContent renders the main content
 func (m Model) renderContent() string {
 	var s strings.Builder
 
-	// Credit section
-	creditBox := m.renderCreditBox()
-	s.WriteString(creditBox)
-	s.WriteString("\n\n")
+	if m.lowCredit() {
+		s.WriteString(m.renderLowCreditBand())
+		s.WriteString("\n\n")
+	}
 
-	// Lines section
-	linesBox := m.renderLinesBox()
-	s.WriteString(linesBox)
+	if m.detail {
+		s.WriteString(m.renderDetail())
+	} else {
+		s.WriteString(m.renderPanels())
+	}
 	s.WriteString("\n\n")
 
 	// Instructions
@@ -156,60 +291,36 @@ func (m Model) renderContent() string {
 	return s.String()
 }
 
-// renderCreditBox renders the credit information box
-func (m Model) renderCreditBox() string {
-	boxStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#f7bd60")).
-		Padding(1, 2).
-		Width(m.width - 4)
-
-	titleStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#f7bd60"))
-
-	valueStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff")).
-		Bold(true)
-
-	title := "💰 Current Credit"
-	value := fmt.Sprintf("%.2f SMS", m.credit)
-
-	content := titleStyle.Render(title) + "\n" + valueStyle.Render(value)
-
-	return boxStyle.Render(content)
+// burnRate describes the credit used per day and how long the rest lasts
+func (m Model) burnRate() string {
+	perDay, ok := history.BurnRate(m.samples)
+	switch {
+	case !ok:
+		return i18n.T("🔥 Burn rate: not enough credit history yet")
+	case perDay == 0:
+		return i18n.T("🔥 Burn rate: no credit used in the last 7 days")
+	}
+	return i18n.Sprintf("🔥 Burn rate: %.1f SMS/day • ⏳ about %.0f day(s) left", perDay, m.credit/perDay)
 }
 
-// renderLinesBox renders the lines information box
-func (m Model) renderLinesBox() string {
-	boxStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#f7bd60")).
-		Padding(1, 2).
-		Width(m.width - 4)
+// lowCredit reports whether the credit is below the configured threshold
+func (m Model) lowCredit() bool {
+	threshold := m.config.Dashboard.LowCredit
+	return threshold > 0 && m.credit < float64(threshold)
+}
 
-	titleStyle := lipgloss.NewStyle().
+// renderLowCreditBand renders the warning shown while credit is low
+func (m Model) renderLowCreditBand() string {
+	bandStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#FF6B6B")).
+		Foreground(lipgloss.Color("#ffffff")).
 		Bold(true).
-		Foreground(lipgloss.Color("#f7bd60"))
-
-	lineStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff"))
-
-	title := "📞 Available Lines"
+		Padding(0, 2).
+		Width(m.width - 2)
 
-	var lines []string
-	for _, line := range m.lines {
-		lines = append(lines, fmt.Sprintf("%d", line))
-	}
-
-	content := titleStyle.Render(title) + "\n"
-	if len(lines) > 0 {
-		content += strings.Join(lines, "\n")
-	} else {
-		content += lineStyle.Render("No lines found")
-	}
-
-	return boxStyle.Render(content)
+	// The band wraps the text inside its padding, so it is wrapped before it is reordered
+	return bandStyle.Render(renderBidi(i18n.Sprintf("⚠️  Low credit: %.2f SMS left, below the warning threshold of %d SMS",
+		m.credit, m.config.Dashboard.LowCredit), m.width-6))
 }
 
 // renderInstructions renders usage instructions
@@ -218,13 +329,21 @@ func (m Model) renderInstructions() string {
 		Foreground(lipgloss.Color("#ffffff")).
 		Align(lipgloss.Center)
 
-	instructions := []string{
-		"Commands:",
-		"r - Refresh data",
-		"q - Quit",
+	instructions := []string{i18n.T("Commands:")}
+	if m.detail {
+		instructions = append(instructions, i18n.T("↑/↓ - Scroll"), i18n.T("esc - Back to panels"))
+	} else {
+		instructions = append(instructions, i18n.T("tab/arrows - Choose panel"), i18n.T("enter - Details"))
+	}
+	instructions = append(instructions, i18n.T("r - Refresh data"), i18n.T("q - Quit"))
+	if m.refresh > 0 {
+		left := max(m.nextRefresh.Sub(m.now).Round(time.Second), 0)
+		instructions = append(instructions, i18n.Sprintf("Auto-refresh in %s", i18n.Digits(left.String())))
+	} else {
+		instructions = append(instructions, i18n.T("Auto-refresh off"))
 	}
 
-	return instructionStyle.Render(strings.Join(instructions, " | "))
+	return instructionStyle.Render(bidiLine(strings.Join(instructions, " | ")))
 }
 
 // Messages
@@ -256,9 +375,14 @@ func loadLines(client *api.Client) tea.Cmd {
 
 // NewModel creates a new TUI model
 func NewModel(client *api.Client, config *config.Config) Model {
+	refresh := time.Duration(config.Dashboard.Refresh) * time.Second
+	now := time.Now()
 	return Model{
-		client:  client,
-		config:  config,
-		loading: true,
+		client:      client,
+		config:      config,
+		loading:     true,
+		refresh:     refresh,
+		now:         now,
+		nextRefresh: now.Add(refresh),
 	}
 }
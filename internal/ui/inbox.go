@@ -0,0 +1,643 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/blocklist"
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/history"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/inbox"
+	"github.com/SaneiyanReza/smsir-cli/internal/state"
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// InboxModel shows the conversations with the numbers that wrote in: the
+// threads on the left, the messages of the selected one on the right and a
+// reply box below them
+type InboxModel struct {
+	client   *api.Client
+	config   *config.Config
+	threads  []inbox.Thread
+	loading  bool
+	err      error
+	width    int
+	height   int
+	quitting bool
+
+	// cursor is the selected thread; read holds when each thread was last
+	// read, as stored in the state file
+	cursor int
+	read   map[string]int64
+	// scroll is how many lines the messages are scrolled up from the latest one
+	scroll int
+
+	// replying is set while the reply box has the focus
+	replying bool
+	editor   textarea.Model
+	sending  bool
+	status   string
+	statusOK bool
+	// confirmingHours tells why the line may not send now while asking
+	// whether to reply anyway
+	confirmingHours string
+}
+
+// threadsMsg carries the conversations loaded from the API and the history
+type threadsMsg []inbox.Thread
+
+// threadsErrMsg carries why the conversations could not be loaded
+type threadsErrMsg struct{ err error }
+
+// replySentMsg carries a reply that was sent
+type replySentMsg struct {
+	mobile  string
+	message inbox.Message
+	// historyErr tells why the reply could not be saved to history
+	historyErr error
+}
+
+// replyErrMsg carries why a reply could not be sent
+type replyErrMsg struct{ err error }
+
+const (
+	// threadListWidth is the widest the list of threads gets
+	threadListWidth = 38
+	// replyHeight is the number of lines of the reply box
+	replyHeight = 3
+)
+
+// Init loads the conversations
+func (m InboxModel) Init() tea.Cmd {
+	return tea.Batch(m.load(), textarea.Blink)
+}
+
+// load fetches the received messages and builds the conversations
+func (m InboxModel) load() tea.Cmd {
+	return func() tea.Msg {
+		threads, err := inbox.Load(m.client, m.config.Profile)
+		if err != nil {
+			return threadsErrMsg{err: err}
+		}
+		return threadsMsg(threads)
+	}
+}
+
+// Update handles messages
+func (m InboxModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case threadsMsg:
+		selected := m.selectedMobile()
+		m.threads = msg
+		m.loading = false
+		m.err = nil
+		// Keep the selection on the same number when the list is reloaded
+		m.cursor = 0
+		for i, t := range m.threads {
+			if t.Mobile == selected {
+				m.cursor = i
+			}
+		}
+		// A thread only counts as read once the user picks it
+		return m, nil
+
+	case threadsErrMsg:
+		m.err = msg.err
+		m.loading = false
+		return m, nil
+
+	case replySentMsg:
+		m.sending = false
+		m.status = i18n.T("✅ Reply sent")
+		m.statusOK = true
+		if msg.historyErr != nil {
+			m.status = i18n.Sprintf("⚠️  Reply sent, but it could not be saved to history: %v", msg.historyErr)
+			m.statusOK = false
+		}
+		m.editor.Reset()
+		for i := range m.threads {
+			if m.threads[i].Mobile == msg.mobile {
+				m.threads[i].Messages = append(m.threads[i].Messages, msg.message)
+			}
+		}
+		m.scroll = 0
+		return m, nil
+
+	case replyErrMsg:
+		m.sending = false
+		m.status = i18n.Sprintf("❌ Reply not sent: %v", msg.err)
+		m.statusOK = false
+		return m, nil
+
+	case tea.KeyMsg:
+		m.editor.SetWidth(m.replyWidth())
+
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		case "f2":
+			return m, toggleBidi()
+		}
+		if m.replying {
+			return m.updateReply(msg)
+		}
+		return m.updateList(msg)
+
+	default:
+		// Cursor blinking of the reply box
+		if m.replying {
+			var cmd tea.Cmd
+			m.editor, cmd = m.editor.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+}
+
+// updateList handles the keys while the list of threads has the focus
+func (m InboxModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		m.quitting = true
+		return m, tea.Quit
+	case "r":
+		m.loading = m.threads == nil
+		return m, m.load()
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+			m.scroll = 0
+			return m, m.markRead()
+		}
+	case "down", "j":
+		if m.cursor < len(m.threads)-1 {
+			m.cursor++
+			m.scroll = 0
+			return m, m.markRead()
+		}
+	case "pgup":
+		m.scroll += m.messageRows() / 2
+	case "pgdown":
+		m.scroll = max(m.scroll-m.messageRows()/2, 0)
+	case "enter", "tab":
+		if len(m.threads) > 0 {
+			m.replying = true
+			m.status = ""
+			return m, tea.Batch(m.editor.Focus(), m.markRead())
+		}
+	}
+	return m, nil
+}
+
+// updateReply handles the keys while the reply box has the focus
+func (m InboxModel) updateReply(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.confirmingHours != "" {
+		return m.answerHours(msg)
+	}
+
+	switch msg.String() {
+	case "esc", "tab":
+		m.replying = false
+		m.editor.Blur()
+		return m, nil
+	case "enter":
+		text := strings.TrimSpace(m.editor.Value())
+		if text == "" || m.sending {
+			return m, nil
+		}
+		// Outside the line's send hours the reply only goes out when confirmed
+		t := m.threads[m.cursor]
+		if line, err := m.config.ResolveLine(strconv.FormatInt(t.Line(), 10)); err == nil {
+			if err := line.CheckHours(time.Now()); err != nil {
+				m.confirmingHours = err.Error()
+				return m, nil
+			}
+		}
+		m.sending = true
+		m.status = ""
+		return m, m.sendReply(t, text, false)
+	case "ctrl+v":
+		if text, err := clipboard.ReadAll(); err == nil {
+			m.editor.InsertString(strings.ReplaceAll(text, "\r\n", "\n"))
+		}
+		return m, nil
+	}
+
+	m.status = ""
+	var cmd tea.Cmd
+	m.editor, cmd = m.editor.Update(msg)
+	return m, cmd
+}
+
+// answerHours handles the answer to whether to reply outside the line's send hours
+func (m InboxModel) answerHours(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.confirmingHours = ""
+		m.sending = true
+		m.status = ""
+		return m, m.sendReply(m.threads[m.cursor], strings.TrimSpace(m.editor.Value()), true)
+	case "n", "N", "esc":
+		m.confirmingHours = ""
+	}
+	return m, nil
+}
+
+// selectedMobile returns the number of the selected thread, or ""
+func (m InboxModel) selectedMobile() string {
+	if m.cursor < len(m.threads) {
+		return m.threads[m.cursor].Mobile
+	}
+	return ""
+}
+
+// markRead marks the selected thread as read up to its latest received
+// message, and stores that in the background. Keeping the marker is best
+// effort: when it cannot be stored, the thread is unread again next time.
+func (m InboxModel) markRead() tea.Cmd {
+	if m.cursor >= len(m.threads) {
+		return nil
+	}
+
+	t := m.threads[m.cursor]
+	at := t.LastReceived().Unix()
+	if m.read[t.Mobile] >= at {
+		return nil
+	}
+	m.read[t.Mobile] = at
+
+	profile := m.config.Profile
+	return func() tea.Msg {
+		_ = state.MarkRead(profile, t.Mobile, at)
+		return nil
+	}
+}
+
+// unread counts the received messages of a thread that were not read yet
+func (m InboxModel) unread(t *inbox.Thread) int {
+	return t.Unread(time.Unix(m.read[t.Mobile], 0))
+}
+
+// sendReply sends text to the number of a thread from the line it wrote to,
+// with the line's signature, and records it in history. The line's send hours
+// are enforced unless ignoreHours is set.
+func (m InboxModel) sendReply(t inbox.Thread, text string, ignoreHours bool) tea.Cmd {
+	return func() tea.Msg {
+		bl, err := blocklist.Load()
+		if err != nil {
+			return replyErrMsg{err: i18n.Errorf("error loading blocklist: %w", err)}
+		}
+		if bl.Contains(t.Mobile) {
+			return replyErrMsg{err: i18n.Errorf("%s is blocklisted", t.Mobile)}
+		}
+
+		line, err := m.config.ResolveLine(strconv.FormatInt(t.Line(), 10))
+		if err != nil {
+			return replyErrMsg{err: err}
+		}
+		lineNumber, err := line.Int()
+		if err != nil {
+			return replyErrMsg{err: err}
+		}
+		if !ignoreHours {
+			if err := line.CheckHours(time.Now()); err != nil {
+				return replyErrMsg{err: err}
+			}
+		}
+		messageText := line.Sign(text)
+
+		resp, err := m.client.SendBulk(api.BulkSendRequest{
+			LineNumber:  lineNumber,
+			MessageText: messageText,
+			Mobiles:     []string{t.Mobile},
+		})
+		if err != nil {
+			return replyErrMsg{err: err}
+		}
+		if !resp.IsSuccess() {
+			return replyErrMsg{err: i18n.Errorf("API error: %s", resp.GetStatusMessage())}
+		}
+
+		historyErr := history.Save(&history.Record{
+			Profile:     m.config.Profile,
+			LineNumber:  lineNumber,
+			MessageText: messageText,
+			Recipients:  []string{t.Mobile},
+			PackID:      resp.Data.PackID,
+			MessageIDs:  resp.Data.MessageIds,
+			Cost:        resp.Data.Cost,
+			Origin:      history.OriginTUI,
+		})
+
+		return replySentMsg{
+			mobile:     t.Mobile,
+			message:    inbox.Message{Time: time.Now(), Line: lineNumber, Text: messageText, Outgoing: true},
+			historyErr: historyErr,
+		}
+	}
+}
+
+// View renders the inbox
+func (m InboxModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var s strings.Builder
+	s.WriteString(m.renderHeader())
+	s.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		loadingStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#f7bd60")).
+			Align(lipgloss.Center)
+		s.WriteString(loadingStyle.Render(bidiLine(i18n.T("Loading... ⏳"))))
+	case m.err != nil:
+		errorStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF6B6B")).
+			Border(lipgloss.RoundedBorder()).
+			Padding(1, 2)
+		s.WriteString(errorStyle.Render(bidiLine(i18n.Sprintf("Error: %v", m.err))))
+	case len(m.threads) == 0:
+		mutedStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF"))
+		s.WriteString(mutedStyle.Render(bidiLine(i18n.Sprintf("No messages received in the last %d days", int(inbox.Window.Hours()/24)))))
+	default:
+		s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, m.renderThreads(), m.renderMessages()))
+		s.WriteString("\n")
+		s.WriteString(m.renderReply())
+	}
+
+	s.WriteString("\n\n")
+	s.WriteString(m.renderInstructions())
+	return s.String()
+}
+
+// renderHeader renders the title with the number of unread messages
+func (m InboxModel) renderHeader() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#f7bd60"))
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	unread := 0
+	for i := range m.threads {
+		unread += m.unread(&m.threads[i])
+	}
+
+	header := titleStyle.Render(bidiLine(i18n.T("📥 Inbox")))
+	if unread > 0 {
+		header += mutedStyle.Render(bidiLine(i18n.Sprintf(" • %d unread", unread)))
+	}
+	return header
+}
+
+// paneHeight is the number of content lines of the thread and message panes
+func (m InboxModel) paneHeight() int {
+	if m.height <= 0 {
+		return 14
+	}
+	// The header, the reply box, the instructions and the blank lines between them
+	return max(4, m.height-replyHeight-11)
+}
+
+// listWidth is the width of the thread list, border included
+func (m InboxModel) listWidth() int {
+	return min(threadListWidth, max(m.viewWidth()/3, 24))
+}
+
+// viewWidth returns the terminal width, or a common width before it is known
+func (m InboxModel) viewWidth() int {
+	if m.width <= 0 {
+		return 80
+	}
+	return m.width
+}
+
+// renderThreads renders the list of threads with their unread markers,
+// scrolled so the selected thread is visible
+func (m InboxModel) renderThreads() string {
+	borderColor := lipgloss.Color("#f7bd60")
+	if m.replying {
+		borderColor = lipgloss.Color("#9CA3AF")
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(0, 1).
+		Width(m.listWidth() - 2).
+		Height(m.paneHeight())
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#f7bd60")).
+		Bold(true)
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff"))
+
+	unreadStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#f7bd60")).
+		Bold(true)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	width := m.listWidth() - 4
+	// Every thread takes two lines
+	visible := max(m.paneHeight()/2, 1)
+	first := max(0, m.cursor-visible+1)
+
+	var lines []string
+	for i := first; i < min(first+visible, len(m.threads)); i++ {
+		t := &m.threads[i]
+		marker := "  "
+		if unread := m.unread(t); unread > 0 {
+			marker = unreadStyle.Render("● ")
+		}
+
+		style := textStyle
+		if i == m.cursor {
+			style = selectedStyle
+			if marker == "  " {
+				marker = selectedStyle.Render("▸ ")
+			}
+		}
+
+		when := i18n.Digits(t.Last().Time.Format("01-02 15:04"))
+		title := t.Mobile + "  " + when
+		if unread := m.unread(t); unread > 0 {
+			title += i18n.Sprintf(" (%d)", unread)
+		}
+		lines = append(lines,
+			marker+style.Render(clipLine(title, width-2)),
+			"  "+mutedStyle.Render(excerpt(t.Last().Text, width-2)))
+	}
+
+	return boxStyle.Render(strings.Join(lines, "\n"))
+}
+
+// messagesWidth is the width of the message pane, border included
+func (m InboxModel) messagesWidth() int {
+	return m.viewWidth() - m.listWidth() - 2
+}
+
+// messageRows is the number of message lines the message pane shows
+func (m InboxModel) messageRows() int {
+	// The title of the pane takes a line
+	return max(m.paneHeight()-1, 1)
+}
+
+// renderMessages renders the messages of the selected thread, oldest first,
+// ending with the latest one unless scrolled up
+func (m InboxModel) renderMessages() string {
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#9CA3AF")).
+		Padding(0, 1).
+		Width(m.messagesWidth() - 2).
+		Height(m.paneHeight())
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#f7bd60"))
+
+	inStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff"))
+
+	outStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#f7bd60"))
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	t := &m.threads[m.cursor]
+	width := m.messagesWidth() - 4
+
+	var lines []string
+	for _, msg := range t.Messages {
+		when := i18n.Digits(msg.Time.Format("2006-01-02 15:04"))
+		header := i18n.Sprintf("← %s • to %d", when, msg.Line)
+		style := inStyle
+		if msg.Outgoing {
+			header = i18n.Sprintf("→ %s • from %d", when, msg.Line)
+			style = outStyle
+		}
+		lines = append(lines, mutedStyle.Render(clipLine(header, width)))
+		for _, line := range strings.Split(renderBidi(msg.Text, width), "\n") {
+			lines = append(lines, style.Render(clip(line, width)))
+		}
+		lines = append(lines, "")
+	}
+	lines = lines[:len(lines)-1]
+
+	rows := m.messageRows()
+	end := len(lines) - min(m.scroll, max(len(lines)-rows, 0))
+	lines = lines[max(end-rows, 0):end]
+
+	line, _ := m.config.ResolveLine(strconv.FormatInt(t.Line(), 10))
+	title := titleStyle.Render(clipLine(i18n.Sprintf("💬 %s • line %s", t.Mobile, line.Label()), width))
+	return boxStyle.Render(title + "\n" + strings.Join(lines, "\n"))
+}
+
+// replyWidth is the width of the reply editor inside its box
+func (m InboxModel) replyWidth() int {
+	return max(m.viewWidth()-6, 20)
+}
+
+// renderReply renders the reply box and the outcome of the last reply
+func (m InboxModel) renderReply() string {
+	borderColor := lipgloss.Color("#9CA3AF")
+	if m.replying {
+		borderColor = lipgloss.Color("#f7bd60")
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(0, 1).
+		Width(m.viewWidth() - 2)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	statusStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B"))
+	if m.statusOK {
+		statusStyle = statusStyle.Foreground(lipgloss.Color("#f7bd60"))
+	}
+
+	t := &m.threads[m.cursor]
+	label := i18n.Sprintf("↩️  Reply to %s", t.Mobile)
+	if m.sending {
+		label = i18n.T("Sending... ⏳")
+	}
+
+	reply := boxStyle.Render(labelStyle.Render(bidiLine(label)) + "\n" + m.editor.View())
+	if m.confirmingHours != "" {
+		promptStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF6B6B")).
+			Bold(true)
+		reply += "\n" + promptStyle.Render(bidiLine(i18n.Sprintf("🕒 %s. Send anyway? y: send • n: keep editing", m.confirmingHours)))
+	} else if m.status != "" {
+		reply += "\n" + statusStyle.Render(bidiLine(m.status))
+	}
+	return reply
+}
+
+// renderInstructions renders the keys of the focused pane
+func (m InboxModel) renderInstructions() string {
+	instructionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff"))
+
+	instructions := []string{i18n.T("Commands:")}
+	if m.confirmingHours != "" {
+		instructions = append(instructions,
+			i18n.T("y - Send anyway"),
+			i18n.T("n - Keep editing"))
+	} else if m.replying {
+		instructions = append(instructions,
+			i18n.T("enter - Send reply"),
+			i18n.T("alt+enter - New line"),
+			i18n.T("esc - Back to conversations"))
+	} else {
+		instructions = append(instructions,
+			i18n.T("↑/↓ - Choose conversation"),
+			i18n.T("enter - Reply"),
+			i18n.T("pgup/pgdn - Scroll"),
+			i18n.T("r - Refresh data"),
+			i18n.T("esc - Back"))
+	}
+
+	return instructionStyle.Render(bidiLine(strings.Join(instructions, " | ")))
+}
+
+// NewInboxModel creates a new inbox model
+func NewInboxModel(client *api.Client, cfg *config.Config) InboxModel {
+	editor := newMessageEditor()
+	editor.SetHeight(replyHeight)
+	editor.Blur()
+
+	return InboxModel{
+		client:  client,
+		config:  cfg,
+		loading: true,
+		read:    state.ReadMarks(cfg.Profile),
+		editor:  editor,
+	}
+}
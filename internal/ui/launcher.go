@@ -6,6 +6,7 @@ import (
 
 	"github.com/SaneiyanReza/smsir-cli/internal/api"
 	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -16,6 +17,7 @@ const (
 	stateConfig    = "config"
 	stateDashboard = "dashboard"
 	stateSend      = "send"
+	stateInbox     = "inbox"
 	stateDone      = "done"
 	stateHelp      = "help"
 	stateExit      = "exit"
@@ -29,6 +31,7 @@ type LauncherModel struct {
 	config        ConfigModel
 	dashboard     Model
 	send          SendModel
+	inbox         InboxModel
 	width         int
 	height        int
 	helpOutput    string
@@ -62,6 +65,10 @@ func (m LauncherModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.send.width = msg.Width
 			m.send.height = msg.Height
 		}
+		if m.inbox.width > 0 || m.state == stateInbox {
+			m.inbox.width = msg.Width
+			m.inbox.height = msg.Height
+		}
 		return m, nil
 
 	default:
@@ -106,20 +113,15 @@ func (m LauncherModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// If config is completed, show success message briefly then return to selector
 			if m.config.completed {
-				// Success message will be cleared when we return to selector
-				m.state = stateSelector
-				m.selector = NewSelectorModel()
-				m.selector.width = m.width
-				m.selector.height = m.height
-				return m, m.selector.Init()
+				return m.backToSelector(toast{text: i18n.T("✅ Configuration saved successfully")})
+			}
+
+			if m.config.err != nil {
+				return m.backToSelector(configErrorToast(i18n.Errorf("Could not save the configuration: %v", m.config.err)))
 			}
 
 			if m.config.quitting {
-				m.state = stateSelector
-				m.selector = NewSelectorModel()
-				m.selector.width = m.width
-				m.selector.height = m.height
-				return m, m.selector.Init()
+				return m.backToSelector(toast{})
 			}
 
 			return m, cmd
@@ -132,11 +134,7 @@ func (m LauncherModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// If dashboard is quitting, return to selector
 			if m.dashboard.quitting {
-				m.state = stateSelector
-				m.selector = NewSelectorModel()
-				m.selector.width = m.width
-				m.selector.height = m.height
-				return m, m.selector.Init()
+				return m.backToSelector(toast{})
 			}
 
 			return m, cmd
@@ -148,11 +146,19 @@ func (m LauncherModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			if m.send.quitting {
-				m.state = stateSelector
-				m.selector = NewSelectorModel()
-				m.selector.width = m.width
-				m.selector.height = m.height
-				return m, m.selector.Init()
+				return m.backToSelector(toast{})
+			}
+
+			return m, cmd
+
+		case stateInbox:
+			inboxModel, cmd := m.inbox.Update(msg)
+			if im, ok := inboxModel.(InboxModel); ok {
+				m.inbox = im
+			}
+
+			if m.inbox.quitting {
+				return m.backToSelector(toast{})
 			}
 
 			return m, cmd
@@ -188,6 +194,8 @@ func (m LauncherModel) View() string {
 		return m.dashboard.View()
 	case stateSend:
 		return m.send.View()
+	case stateInbox:
+		return m.inbox.View()
 	case stateHelp:
 		return m.helpOutput
 	case stateDone, stateExit:
@@ -200,31 +208,19 @@ func (m LauncherModel) View() string {
 // handleSelection handles the user's selection and transitions to appropriate state
 func (m *LauncherModel) handleSelection() (tea.Model, tea.Cmd) {
 	switch m.selector.Selected {
-	case "🔧 Configure API Key & Line Number":
+	case configChoice:
 		// Transition to config state
 		m.state = stateConfig
 		m.config = NewConfigModel()
 		m.config.width = m.width
 		m.config.height = m.height
-		return m, nil
+		return m, m.config.Init()
 
 	case "📤 Send SMS":
 		// Load config and transition to send state
-		cfg, err := config.LoadConfig()
+		cfg, err := m.loadConfig()
 		if err != nil {
-			m.state = stateSelector
-			m.selector = NewSelectorModel()
-			m.selector.width = m.width
-			m.selector.height = m.height
-			return m, m.selector.Init()
-		}
-
-		if err := cfg.Validate(); err != nil {
-			m.state = stateSelector
-			m.selector = NewSelectorModel()
-			m.selector.width = m.width
-			m.selector.height = m.height
-			return m, m.selector.Init()
+			return m.backToSelector(configErrorToast(err))
 		}
 
 		client := api.NewClient(cfg)
@@ -234,6 +230,20 @@ func (m *LauncherModel) handleSelection() (tea.Model, tea.Cmd) {
 		m.send.height = m.height
 		return m, m.send.Init()
 
+	case "📥 Inbox":
+		// Load config and transition to inbox state
+		cfg, err := m.loadConfig()
+		if err != nil {
+			return m.backToSelector(configErrorToast(err))
+		}
+
+		client := api.NewClient(cfg)
+		m.state = stateInbox
+		m.inbox = NewInboxModel(client, cfg)
+		m.inbox.width = m.width
+		m.inbox.height = m.height
+		return m, m.inbox.Init()
+
 	case "💻 Command Line Mode":
 		// Exit UI and run help command
 		m.shouldRunHelp = true
@@ -242,22 +252,9 @@ func (m *LauncherModel) handleSelection() (tea.Model, tea.Cmd) {
 
 	case "📊 Dashboard":
 		// Load config and transition to dashboard state
-		cfg, err := config.LoadConfig()
+		cfg, err := m.loadConfig()
 		if err != nil {
-			m.state = stateSelector
-			m.selector = NewSelectorModel()
-			m.selector.width = m.width
-			m.selector.height = m.height
-			// Show error message (will be displayed when selector is shown)
-			return m, m.selector.Init()
-		}
-
-		if err := cfg.Validate(); err != nil {
-			m.state = stateSelector
-			m.selector = NewSelectorModel()
-			m.selector.width = m.width
-			m.selector.height = m.height
-			return m, m.selector.Init()
+			return m.backToSelector(configErrorToast(err))
 		}
 
 		client := api.NewClient(cfg)
@@ -269,13 +266,43 @@ func (m *LauncherModel) handleSelection() (tea.Model, tea.Cmd) {
 	}
 
 	// Default: return to selector
+	return m.backToSelector(toast{})
+}
+
+// backToSelector shows a new selector with a toast, e.g. why the last choice failed
+func (m *LauncherModel) backToSelector(t toast) (tea.Model, tea.Cmd) {
 	m.state = stateSelector
 	m.selector = NewSelectorModel()
 	m.selector.width = m.width
 	m.selector.height = m.height
+	m.selector.toast = t
 	return m, m.selector.Init()
 }
 
+// loadConfig loads the configuration the dashboard, send and inbox screens need
+func (m *LauncherModel) loadConfig() (*config.Config, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, i18n.Errorf("Could not load the configuration: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, i18n.Errorf("The configuration is incomplete: %v", err)
+	}
+	return cfg, nil
+}
+
+// configErrorToast explains a configuration problem and offers the config wizard
+func configErrorToast(err error) toast {
+	return toast{text: "❌ " + err.Error(), isError: true, offerConfig: true}
+}
+
+// WithConfigError shows a configuration problem found before the menu
+// started, e.g. a wrong passphrase, with the offer to open the config wizard
+func (m LauncherModel) WithConfigError(err error) LauncherModel {
+	m.selector.toast = configErrorToast(err)
+	return m
+}
+
 // getHelpOutput gets the help output by running the command
 func (m *LauncherModel) getHelpOutput() string {
 	// Get help output from the actual command
@@ -286,23 +313,23 @@ func (m *LauncherModel) getHelpOutput() string {
 	}
 
 	var output strings.Builder
-	output.WriteString("SMS.ir CLI - Command Line Mode\n\n")
-	output.WriteString("Available Commands:\n")
-	output.WriteString("  config    Configuration management\n")
-	output.WriteString("  send      Send SMS message\n")
-	output.WriteString("  credit    Show current credit balance\n")
-	output.WriteString("  lines     Show available lines\n")
-	output.WriteString("  menu      Launch interactive menu\n")
-	output.WriteString("  help      Help about any command\n\n")
-	output.WriteString("Usage:\n")
+	output.WriteString(i18n.T("SMS.ir CLI - Command Line Mode") + "\n\n")
+	output.WriteString(i18n.T("Available Commands:") + "\n")
+	output.WriteString("  config    " + i18n.T("Configuration management") + "\n")
+	output.WriteString("  send      " + i18n.T("Send SMS message") + "\n")
+	output.WriteString("  credit    " + i18n.T("Show current credit balance") + "\n")
+	output.WriteString("  lines     " + i18n.T("Show available lines") + "\n")
+	output.WriteString("  menu      " + i18n.T("Launch interactive menu") + "\n")
+	output.WriteString("  help      " + i18n.T("Help about any command") + "\n\n")
+	output.WriteString(i18n.T("Usage:") + "\n")
 	output.WriteString("  " + cmd + " [command]\n\n")
-	output.WriteString("Examples:\n")
+	output.WriteString(i18n.T("Examples:") + "\n")
 	output.WriteString("  " + cmd + " config set --api-key YOUR_KEY --line YOUR_LINE\n")
 	output.WriteString("  " + cmd + " send -m \"Hello\" -t \"09120000000,09121111111\"\n\n")
 	output.WriteString("  " + cmd + " credit\n")
 	output.WriteString("  " + cmd + " lines\n")
-	output.WriteString("For more information, run: " + cmd + " --help\n\n")
-	output.WriteString("Press any key to exit...")
+	output.WriteString(i18n.Sprintf("For more information, run: %s --help", cmd) + "\n\n")
+	output.WriteString(i18n.T("Press any key to exit..."))
 
 	return output.String()
 }
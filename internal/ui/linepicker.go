@@ -0,0 +1,246 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LinePicker lets the user choose a sender line from the account's lines,
+// fetched asynchronously, with manual entry as a fallback
+type LinePicker struct {
+	client      *api.Client
+	spinner     spinner.Model
+	loading     bool
+	lines       []string
+	cursor      int
+	manual      bool
+	input       string
+	err         error
+	defaultLine string
+	lastUsed    string
+	aliases     func(number string) []string
+}
+
+// linesLoadedMsg carries the lines fetched for a LinePicker
+type linesLoadedMsg struct {
+	lines []string
+	err   error
+}
+
+// NewLinePicker creates a line picker for a profile. Its configured line is
+// marked as the default, its line aliases are shown next to the numbers and
+// lastUsed is preselected when it is one of the account's lines.
+func NewLinePicker(client *api.Client, cfg *config.Config, lastUsed string) LinePicker {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#f7bd60"))
+
+	defaultLine := cfg.LineNumber
+	if line, err := cfg.ResolveLine(cfg.LineNumber); err == nil {
+		defaultLine = line.Number
+	}
+
+	return LinePicker{
+		client:      client,
+		spinner:     s,
+		loading:     true,
+		defaultLine: defaultLine,
+		lastUsed:    lastUsed,
+		aliases:     cfg.LineAliases,
+	}
+}
+
+// Init starts fetching the lines
+func (p LinePicker) Init() tea.Cmd {
+	return tea.Batch(p.spinner.Tick, p.fetchLines())
+}
+
+// fetchLines loads the account's lines
+func (p LinePicker) fetchLines() tea.Cmd {
+	client := p.client
+	return func() tea.Msg {
+		resp, err := client.GetLines()
+		if err != nil {
+			return linesLoadedMsg{err: err}
+		}
+		if !resp.IsSuccess() {
+			return linesLoadedMsg{err: i18n.Errorf("API error: %s", resp.GetStatusMessage())}
+		}
+
+		lines := make([]string, len(resp.Data))
+		for i, line := range resp.Data {
+			lines[i] = strconv.FormatInt(line, 10)
+		}
+		return linesLoadedMsg{lines: lines}
+	}
+}
+
+// Update handles messages; Enter is left to the parent model, which reads Value
+func (p LinePicker) Update(msg tea.Msg) (LinePicker, tea.Cmd) {
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		if !p.loading {
+			return p, nil
+		}
+		var cmd tea.Cmd
+		p.spinner, cmd = p.spinner.Update(msg)
+		return p, cmd
+
+	case linesLoadedMsg:
+		p.loading = false
+		p.err = msg.err
+		p.lines = msg.lines
+		if p.err != nil || len(p.lines) == 0 {
+			// Nothing to choose from; fall back to typing the line
+			p.manual = true
+			p.input = p.preferred()
+			return p, nil
+		}
+		p.cursor = 0
+		for i, line := range p.lines {
+			if line == p.preferred() {
+				p.cursor = i
+				break
+			}
+		}
+		return p, nil
+
+	case tea.KeyMsg:
+		if p.loading {
+			return p, nil
+		}
+
+		switch msg.String() {
+		case "tab":
+			// Switch between the list and manual entry
+			if len(p.lines) > 0 {
+				p.manual = !p.manual
+			}
+			return p, nil
+		}
+
+		if p.manual {
+			switch msg.Type {
+			case tea.KeyBackspace:
+				if runes := []rune(p.input); len(runes) > 0 {
+					p.input = string(runes[:len(runes)-1])
+				}
+			case tea.KeyRunes:
+				p.input += msg.String()
+			}
+			return p, nil
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+		case "down", "j":
+			if p.cursor < len(p.lines)-1 {
+				p.cursor++
+			}
+		}
+		return p, nil
+	}
+
+	return p, nil
+}
+
+// preferred returns the line to preselect: the last used one, then the configured default
+func (p LinePicker) preferred() string {
+	for _, line := range p.lines {
+		if line == p.lastUsed {
+			return line
+		}
+	}
+	if p.lastUsed != "" && len(p.lines) == 0 {
+		return p.lastUsed
+	}
+	return p.defaultLine
+}
+
+// Loading reports whether the lines are still being fetched
+func (p LinePicker) Loading() bool {
+	return p.loading
+}
+
+// Manual reports whether the line is being typed instead of picked
+func (p LinePicker) Manual() bool {
+	return p.manual
+}
+
+// SetInput replaces the manually entered line, e.g. after a paste
+func (p *LinePicker) SetInput(text string) {
+	p.manual = true
+	p.input = text
+}
+
+// Value returns the chosen or entered line number
+func (p LinePicker) Value() string {
+	if p.manual {
+		return strings.TrimSpace(p.input)
+	}
+	if p.cursor < len(p.lines) {
+		return p.lines[p.cursor]
+	}
+	return ""
+}
+
+// View renders the picker
+func (p LinePicker) View() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#f7bd60")).Bold(true)
+	inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ffffff")).Bold(true)
+
+	if p.loading {
+		return p.spinner.View() + " " + mutedStyle.Render(bidiLine(i18n.T("Loading your lines...")))
+	}
+
+	var s strings.Builder
+	if p.manual {
+		if p.err != nil {
+			s.WriteString(errorStyle.Render(bidiLine(i18n.Sprintf("Could not load lines: %v", p.err))))
+			s.WriteString("\n\n")
+		}
+		if p.input == "" {
+			s.WriteString(mutedStyle.Italic(true).Render(bidiLine(i18n.T("Type the line number..."))))
+		} else {
+			s.WriteString(inputStyle.Render(p.input))
+		}
+		if len(p.lines) > 0 {
+			s.WriteString("\n\n" + mutedStyle.Render(bidiLine(i18n.T("Tab: back to the list"))))
+		}
+		return s.String()
+	}
+
+	for i, line := range p.lines {
+		marks := p.aliases(line)
+		if line == p.defaultLine {
+			marks = append(marks, i18n.T("default"))
+		}
+		if line == p.lastUsed {
+			marks = append(marks, i18n.T("last used"))
+		}
+		var note string
+		if len(marks) > 0 {
+			note = " " + mutedStyle.Render(bidiLine("("+strings.Join(marks, ", ")+")"))
+		}
+
+		if i == p.cursor {
+			s.WriteString(selectedStyle.Render("▶ "+line) + note + "\n")
+		} else {
+			s.WriteString("  " + line + note + "\n")
+		}
+	}
+	s.WriteString("\n" + mutedStyle.Render(bidiLine(i18n.T("↑/↓: choose • Tab: type a line manually"))))
+	return s.String()
+}
@@ -0,0 +1,552 @@
+package ui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/history"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/phone"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// panel is one of the boxes of the dashboard
+type panel int
+
+const (
+	panelCredit panel = iota
+	panelLines
+	panelRecent
+	panelToday
+	panelInbox
+	panelScheduled
+	// panelCount is the number of panels
+	panelCount
+)
+
+const (
+	// minPanelWidth is the narrowest a panel gets before the grid drops a column
+	minPanelWidth = 44
+	// maxPanelColumns is the most panels shown side by side
+	maxPanelColumns = 3
+	// defaultPanelRows is how many lines a panel shows while the terminal height is unknown
+	defaultPanelRows = 5
+	// maxPanelRows is the most lines a panel of the grid shows
+	maxPanelRows = 8
+	// inboxCount is how many received messages the inbox panel fetches
+	inboxCount = 50
+)
+
+// title returns the heading of the panel
+func (p panel) title() string {
+	switch p {
+	case panelCredit:
+		return i18n.T("💰 Current Credit")
+	case panelLines:
+		return i18n.T("📞 Available Lines")
+	case panelRecent:
+		return i18n.T("📤 Recent Sends")
+	case panelToday:
+		return i18n.T("📅 Today")
+	case panelInbox:
+		return i18n.T("📥 Inbox")
+	default:
+		return i18n.T("🕒 Scheduled")
+	}
+}
+
+// activity is the part of the local send history the dashboard shows
+type activity struct {
+	// recent are the last sends that went out, newest first
+	recent []history.Record
+	// today are the sends that went out since midnight
+	today []history.Record
+	// scheduled are the sends that have not gone out yet, soonest first
+	scheduled []history.Record
+	err       error
+}
+
+// activityMsg carries the sends read from the history
+type activityMsg activity
+
+// inboxMsg carries the latest received messages, newest first
+type inboxMsg struct {
+	messages []api.ReceivedMessage
+	err      error
+}
+
+// loadActivity reads the last recent sends, today's sends and the scheduled
+// ones of a profile from the history
+func loadActivity(profile string, recent int) tea.Cmd {
+	return func() tea.Msg {
+		store, err := history.Open()
+		if err != nil {
+			return activityMsg{err: err}
+		}
+		defer store.Close()
+
+		records, err := store.List(history.Filter{})
+		if err != nil {
+			return activityMsg{err: err}
+		}
+
+		now := time.Now()
+		year, month, day := now.Date()
+		midnight := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+
+		var a activity
+		for _, r := range records {
+			if r.Profile != profile {
+				continue
+			}
+			at := sentAt(r)
+			if at.After(now) {
+				a.scheduled = append(a.scheduled, r)
+				continue
+			}
+			if len(a.recent) < recent {
+				a.recent = append(a.recent, r)
+			}
+			if !at.Before(midnight) {
+				a.today = append(a.today, r)
+			}
+		}
+		sort.Slice(a.scheduled, func(i, j int) bool {
+			return a.scheduled[i].SendAt.Before(a.scheduled[j].SendAt)
+		})
+		return activityMsg(a)
+	}
+}
+
+// loadInbox fetches the latest received messages. A failure only shows in
+// the inbox panel, the rest of the dashboard does not depend on it.
+func loadInbox(client *api.Client) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := client.GetLatestReceived(inboxCount)
+		if err != nil {
+			return inboxMsg{err: err}
+		}
+		if err := resp.Err(); err != nil {
+			return inboxMsg{err: err}
+		}
+
+		messages := []api.ReceivedMessage(resp.Data)
+		sort.SliceStable(messages, func(i, j int) bool {
+			return messages[i].ReceivedDateTime > messages[j].ReceivedDateTime
+		})
+		return inboxMsg{messages: messages}
+	}
+}
+
+// sentAt returns when a send goes out: the time it was scheduled for, or else when it was made
+func sentAt(r history.Record) time.Time {
+	if !r.SendAt.IsZero() {
+		return r.SendAt
+	}
+	return r.Timestamp
+}
+
+// columns returns how many panels fit side by side
+func (m Model) columns() int {
+	return max(1, min(maxPanelColumns, m.viewWidth()/minPanelWidth))
+}
+
+// viewWidth returns the terminal width, or a common width before it is known
+func (m Model) viewWidth() int {
+	if m.width <= 0 {
+		return 80
+	}
+	return m.width
+}
+
+// panelRows returns how many lines of content each panel of the grid has
+// room for when the grid has cols columns
+func (m Model) panelRows(cols int) int {
+	if m.height <= 0 {
+		return defaultPanelRows
+	}
+
+	gridRows := (int(panelCount) + cols - 1) / cols
+	// The header, the instructions and the blank lines between them
+	free := m.height - 6
+	if m.lowCredit() {
+		free -= 2
+	}
+	// Every panel also has a border and a title
+	return max(1, min(maxPanelRows, free/gridRows-3))
+}
+
+// detailRows returns how many lines of content the detail view has room for
+func (m Model) detailRows() int {
+	if m.height <= 0 {
+		return 2 * maxPanelRows
+	}
+	free := m.height - 6
+	if m.lowCredit() {
+		free -= 2
+	}
+	return max(1, free-3)
+}
+
+// maxOffset returns how far the detail view can scroll down
+func (m Model) maxOffset() int {
+	return max(0, len(m.panelContent(m.focus, m.viewWidth()-6, true))-m.detailRows())
+}
+
+// renderPanels renders the panels as a grid that fills the terminal width
+func (m Model) renderPanels() string {
+	cols := m.columns()
+	width := (m.viewWidth() - 2) / cols
+	rows := m.panelRows(cols)
+
+	var grid []string
+	for first := panel(0); first < panelCount; first += panel(cols) {
+		var row []string
+		for p := first; p < min(first+panel(cols), panelCount); p++ {
+			row = append(row, m.renderPanel(p, width, rows))
+		}
+		grid = append(grid, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, grid...)
+}
+
+// renderPanel renders a panel of the grid width columns wide, with rows
+// lines of content. The focused panel has a highlighted border.
+func (m Model) renderPanel(p panel, width, rows int) string {
+	borderColor := lipgloss.Color("#9CA3AF")
+	if p == m.focus {
+		borderColor = lipgloss.Color("#f7bd60")
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(0, 1).
+		Width(width - 2).
+		Height(rows + 1)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#f7bd60"))
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	inner := width - 4
+	content := m.panelContent(p, inner, false)
+	if len(content) > rows {
+		more := i18n.Sprintf("… %d more, enter for details", len(content)-rows+1)
+		content = append(content[:rows-1], mutedStyle.Render(clipLine(more, inner)))
+	}
+
+	return boxStyle.Render(titleStyle.Render(clipLine(p.title(), inner)) + "\n" + strings.Join(content, "\n"))
+}
+
+// renderDetail renders the focused panel over the full width with all of its
+// content, scrolled to the current offset
+func (m Model) renderDetail() string {
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#f7bd60")).
+		Padding(0, 2).
+		Width(m.viewWidth() - 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#f7bd60"))
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	content := m.panelContent(m.focus, m.viewWidth()-6, true)
+	rows := m.detailRows()
+	title := titleStyle.Render(bidiLine(m.focus.title()))
+	if len(content) > rows {
+		offset := min(m.offset, len(content)-rows)
+		title += mutedStyle.Render(bidiLine(i18n.Sprintf("  %d-%d of %d", offset+1, offset+rows, len(content))))
+		content = content[offset : offset+rows]
+	}
+
+	return boxStyle.Render(title + "\n" + strings.Join(content, "\n"))
+}
+
+// panelContent returns the lines of a panel, each at most width columns.
+// The detail view shows more of them than the grid.
+func (m Model) panelContent(p panel, width int, detail bool) []string {
+	switch p {
+	case panelCredit:
+		return m.creditContent(width, detail)
+	case panelLines:
+		return m.linesContent(width)
+	case panelRecent:
+		return m.recentContent(width, detail)
+	case panelToday:
+		return m.todayContent(width, detail)
+	case panelInbox:
+		return m.inboxContent(width, detail)
+	default:
+		return m.scheduledContent(width, detail)
+	}
+}
+
+// creditContent shows the credit, its sparkline and burn rate, and in the
+// detail view every stored reading
+func (m Model) creditContent(width int, detail bool) []string {
+	valueStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff")).
+		Bold(true)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	sparkStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#f7bd60"))
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff"))
+
+	if m.lowCredit() {
+		valueStyle = valueStyle.Foreground(lipgloss.Color("#FF6B6B"))
+	}
+
+	content := []string{valueStyle.Render(clipLine(i18n.Sprintf("%.2f SMS", m.credit), width))}
+	if len(m.samples) > 1 {
+		values := make([]float64, len(m.samples))
+		for i, sample := range m.samples {
+			values[i] = sample.Credit
+		}
+		content = append(content, sparkStyle.Render(sparkline(values, min(sparklineWidth, width))))
+	}
+	content = append(content, mutedStyle.Render(clipLine(m.burnRate(), width)))
+
+	if !detail || len(m.samples) == 0 {
+		return content
+	}
+
+	since := i18n.Sprintf("Credit since %s", i18n.Digits(m.samples[0].Timestamp.Format("2006-01-02 15:04")))
+	content = append(content, "", mutedStyle.Render(clipLine(since, width)))
+	for i := len(m.samples) - 1; i >= 0; i-- {
+		sample := m.samples[i]
+		reading := i18n.Sprintf("%s  %.2f SMS", i18n.Digits(sample.Timestamp.Format("2006-01-02 15:04")), sample.Credit)
+		content = append(content, textStyle.Render(clipLine(reading, width)))
+	}
+	return content
+}
+
+// linesContent lists the account's lines with their aliases
+func (m Model) linesContent(width int) []string {
+	lineStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff"))
+
+	if len(m.lines) == 0 {
+		return []string{lineStyle.Render(bidiLine(i18n.T("No lines found")))}
+	}
+
+	content := make([]string, 0, len(m.lines))
+	for _, line := range m.lines {
+		text := i18n.Sprintf("%d", line)
+		if aliases := m.config.LineAliases(strconv.FormatInt(line, 10)); len(aliases) > 0 {
+			text = i18n.Sprintf("%d (%s)", line, strings.Join(aliases, ", "))
+		}
+		content = append(content, lineStyle.Render(clipLine(text, width)))
+	}
+	return content
+}
+
+// recentContent lists the last sends with the share of their messages that
+// was delivered. The detail view adds the delivery counts and the message.
+func (m Model) recentContent(width int, detail bool) []string {
+	if m.activity.err != nil {
+		return []string{renderPanelError(i18n.Sprintf("History unavailable: %v", m.activity.err), width)}
+	}
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff"))
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	if len(m.activity.recent) == 0 {
+		return []string{mutedStyle.Render(clipLine(i18n.T("No sends yet"), width))}
+	}
+
+	var content []string
+	for _, r := range m.activity.recent {
+		summary := r.Summary()
+		rate := i18n.T("no report yet")
+		if !r.SyncedAt.IsZero() && summary.Total > 0 {
+			rate = i18n.Sprintf("%.0f%% delivered", float64(summary.Delivered)/float64(summary.Total)*100)
+		}
+		row := i18n.Sprintf("%s • %d • %d recipient(s) • %s",
+			i18n.Digits(sentAt(r).Format("01-02 15:04")), r.LineNumber, len(r.Recipients), rate)
+		content = append(content, textStyle.Render(clipLine(row, width)))
+
+		if detail {
+			counts := i18n.Sprintf("   pack %s • ✅ %d • ❌ %d • ⏳ %d • 💰 %.2f SMS",
+				r.PackID, summary.Delivered, summary.Failed, summary.Pending, r.Cost)
+			content = append(content,
+				mutedStyle.Render(clipLine(counts, width)),
+				mutedStyle.Render("   "+excerpt(r.MessageText, width-3)))
+		}
+	}
+	return content
+}
+
+// todayContent counts today's messages by their delivery outcome. The
+// detail view breaks the counts down by line.
+func (m Model) todayContent(width int, detail bool) []string {
+	if m.activity.err != nil {
+		return []string{renderPanelError(i18n.Sprintf("History unavailable: %v", m.activity.err), width)}
+	}
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff"))
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	var total api.DeliverySummary
+	var cost float64
+	byLine := make(map[int64]*api.DeliverySummary)
+	var lines []int64
+	for _, r := range m.activity.today {
+		summary := r.Summary()
+		total.Total += summary.Total
+		total.Delivered += summary.Delivered
+		total.Failed += summary.Failed
+		total.Pending += summary.Pending
+		cost += r.Cost
+
+		line, ok := byLine[r.LineNumber]
+		if !ok {
+			line = &api.DeliverySummary{}
+			byLine[r.LineNumber] = line
+			lines = append(lines, r.LineNumber)
+		}
+		line.Total += summary.Total
+		line.Delivered += summary.Delivered
+		line.Failed += summary.Failed
+		line.Pending += summary.Pending
+	}
+
+	content := []string{
+		textStyle.Render(clipLine(i18n.Sprintf("📤 Sent: %d", total.Total), width)),
+		textStyle.Render(clipLine(i18n.Sprintf("✅ Delivered: %d", total.Delivered), width)),
+		textStyle.Render(clipLine(i18n.Sprintf("❌ Failed: %d", total.Failed), width)),
+		textStyle.Render(clipLine(i18n.Sprintf("⏳ Pending: %d", total.Pending), width)),
+		mutedStyle.Render(clipLine(i18n.Sprintf("💰 Cost: %.2f SMS", cost), width)),
+	}
+	if !detail || len(lines) == 0 {
+		return content
+	}
+
+	sort.Slice(lines, func(i, j int) bool { return lines[i] < lines[j] })
+	content = append(content, "")
+	for _, number := range lines {
+		s := byLine[number]
+		row := i18n.Sprintf("%d: 📤 %d • ✅ %d • ❌ %d • ⏳ %d", number, s.Total, s.Delivered, s.Failed, s.Pending)
+		content = append(content, textStyle.Render(clipLine(row, width)))
+	}
+	return content
+}
+
+// inboxContent shows the latest received message of each line, and every
+// fetched message in the detail view
+func (m Model) inboxContent(width int, detail bool) []string {
+	if m.inboxErr != nil {
+		return []string{renderPanelError(i18n.Sprintf("Inbox unavailable: %v", m.inboxErr), width)}
+	}
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff"))
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	if len(m.inbox) == 0 {
+		return []string{mutedStyle.Render(clipLine(i18n.T("No messages received"), width))}
+	}
+
+	var content []string
+	seen := make(map[int64]bool)
+	for _, msg := range m.inbox {
+		if !detail {
+			if seen[msg.Number] {
+				continue
+			}
+			seen[msg.Number] = true
+		}
+
+		received := time.Unix(msg.ReceivedDateTime, 0).Format("01-02 15:04")
+		from := i18n.Sprintf("%s • %s → %d", i18n.Digits(received), phone.FromInt(msg.Mobile), msg.Number)
+		content = append(content,
+			textStyle.Render(clipLine(from, width)),
+			mutedStyle.Render("   "+excerpt(msg.MessageText, width-3)))
+	}
+	return content
+}
+
+// scheduledContent lists the sends that have not gone out yet, with the
+// message in the detail view
+func (m Model) scheduledContent(width int, detail bool) []string {
+	if m.activity.err != nil {
+		return []string{renderPanelError(i18n.Sprintf("History unavailable: %v", m.activity.err), width)}
+	}
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff"))
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	if len(m.activity.scheduled) == 0 {
+		return []string{mutedStyle.Render(clipLine(i18n.T("Nothing scheduled"), width))}
+	}
+
+	var content []string
+	for _, r := range m.activity.scheduled {
+		left := strings.TrimSuffix(max(r.SendAt.Sub(m.now).Round(time.Minute), 0).String(), "0s")
+		if left == "" {
+			left = "1m"
+		}
+		row := i18n.Sprintf("%s • %d • %d recipient(s) • in %s",
+			i18n.Digits(r.SendAt.Format("01-02 15:04")), r.LineNumber, len(r.Recipients), i18n.Digits(left))
+		content = append(content, textStyle.Render(clipLine(row, width)))
+
+		if detail {
+			content = append(content,
+				mutedStyle.Render(clipLine(i18n.Sprintf("   pack %s • 💰 %.2f SMS", r.PackID, r.Cost), width)),
+				mutedStyle.Render("   "+excerpt(r.MessageText, width-3)))
+		}
+	}
+	return content
+}
+
+// renderPanelError renders why a panel has no content
+func renderPanelError(err string, width int) string {
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B"))
+
+	return errorStyle.Render(clipLine("⚠️  "+err, width))
+}
+
+// clip cuts text to at most width columns
+func clip(text string, width int) string {
+	return lipgloss.NewStyle().MaxWidth(max(width, 1)).Render(text)
+}
+
+// clipLine cuts a line of text to at most width columns, in visual order
+// when bidi rendering is on
+func clipLine(text string, width int) string {
+	return bidiLine(clip(text, width))
+}
+
+// excerpt puts a message on a single line of at most width columns, in
+// visual order when bidi rendering is on
+func excerpt(text string, width int) string {
+	return clipLine(strings.Join(strings.Fields(text), " "), width)
+}
@@ -3,7 +3,9 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -16,11 +18,37 @@ type SelectorModel struct {
 	quitting bool
 	width    int
 	height   int
+	toast    toast
 }
 
+// toast is a message shown above the choices, e.g. why the last choice failed
+type toast struct {
+	text    string
+	isError bool
+	// offerConfig lets c open the config wizard to fix the problem
+	offerConfig bool
+}
+
+// toastExpiredMsg hides a toast that was shown for toastDuration
+type toastExpiredMsg struct {
+	toast toast
+}
+
+// toastDuration is how long a success toast is shown; errors stay until dismissed
+const toastDuration = 4 * time.Second
+
+// configChoice is the choice that opens the config wizard
+const configChoice = "🔧 Configure API Key & Line Number"
+
 // Init initializes the selector model
 func (m SelectorModel) Init() tea.Cmd {
-	return nil
+	if m.toast.text == "" || m.toast.isError {
+		return nil
+	}
+	shown := m.toast
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{toast: shown}
+	})
 }
 
 // Update handles messages
@@ -31,12 +59,31 @@ func (m SelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case toastExpiredMsg:
+		// A newer toast stays
+		if msg.toast == m.toast {
+			m.toast = toast{}
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "q":
 			m.quitting = true
 			return m, tea.Quit
 
+		case "esc":
+			m.toast = toast{}
+
+		case "f2":
+			return m, toggleBidi()
+
+		case "c":
+			if m.toast.offerConfig {
+				m.Selected = configChoice
+				return m, tea.Quit
+			}
+
 		case "enter":
 			m.Selected = m.choices[m.cursor]
 			return m, tea.Quit
@@ -62,7 +109,7 @@ func (m SelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View renders the selector
 func (m SelectorModel) View() string {
 	if m.quitting {
-		return "Goodbye! 👋\n"
+		return i18n.T("Goodbye! 👋\n")
 	}
 
 	var s strings.Builder
@@ -77,6 +124,11 @@ func (m SelectorModel) View() string {
 	s.WriteString(instructions)
 	s.WriteString("\n\n")
 
+	if m.toast.text != "" {
+		s.WriteString(m.renderToast())
+		s.WriteString("\n\n")
+	}
+
 	// Choices
 	choices := m.renderChoices()
 	s.WriteString(choices)
@@ -97,9 +149,9 @@ func (m SelectorModel) renderHeader() string {
 		Italic(true)
 
 	title := "📱 SMS.ir CLI"
-	subtitle := "A simple message can connect worlds with a single command"
+	subtitle := i18n.T("A simple message can connect worlds with a single command")
 
-	return titleStyle.Render(title) + "\n" + subtitleStyle.Render(subtitle)
+	return titleStyle.Render(title) + "\n" + subtitleStyle.Render(bidiLine(subtitle))
 }
 
 // renderInstructions renders instructions
@@ -109,12 +161,50 @@ func (m SelectorModel) renderInstructions() string {
 		Align(lipgloss.Center)
 
 	instructions := []string{
-		"Use ↑/↓ or j/k to navigate",
-		"Press Enter to select",
-		"Press q or Ctrl+C to quit",
+		i18n.T("Use ↑/↓ or j/k to navigate"),
+		i18n.T("Press Enter to select"),
+		i18n.T("Press q or Ctrl+C to quit"),
+		i18n.Sprintf("F2: %s", bidiToggleLabel()),
+	}
+
+	return instructionStyle.Render(bidiLine(strings.Join(instructions, " • ")))
+}
+
+// renderToast renders the toast and the keys it offers
+func (m SelectorModel) renderToast() string {
+	color := lipgloss.Color("#f7bd60")
+	if m.toast.isError {
+		color = lipgloss.Color("#FF6B6B")
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(color).
+		Padding(0, 1)
+	text := bidiLine(m.toast.text)
+	if m.width > 0 {
+		boxStyle = boxStyle.Width(min(m.width, 80) - 4)
+		// The box wraps the text, so it is wrapped before it is reordered
+		text = renderBidi(m.toast.text, min(m.width, 80)-6)
+	}
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(color)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	content := textStyle.Render(text)
+	if m.toast.isError {
+		var keys []string
+		if m.toast.offerConfig {
+			keys = append(keys, i18n.T("c: open the configuration wizard"))
+		}
+		keys = append(keys, i18n.T("Esc: dismiss"))
+		content += "\n" + mutedStyle.Render(bidiLine(strings.Join(keys, " • ")))
 	}
 
-	return instructionStyle.Render(strings.Join(instructions, " • "))
+	return boxStyle.Render(content)
 }
 
 // renderChoices renders the choice list
@@ -136,7 +226,7 @@ func (m SelectorModel) renderChoices() string {
 				Bold(true)
 		}
 
-		choiceText := fmt.Sprintf("%s %s", cursor, choice)
+		choiceText := fmt.Sprintf("%s %s", cursor, bidiLine(i18n.T(choice)))
 		s.WriteString(choiceStyle.Render(choiceText))
 		s.WriteString("\n")
 	}
@@ -147,10 +237,12 @@ func (m SelectorModel) renderChoices() string {
 // NewSelectorModel creates a new selector model
 func NewSelectorModel() SelectorModel {
 	return SelectorModel{
+		// The choices are translated when rendered; Selected holds the English text
 		choices: []string{
-			"🔧 Configure API Key & Line Number",
+			configChoice,
 			"📤 Send SMS",
 			"📊 Dashboard",
+			"📥 Inbox",
 			"💻 Command Line Mode",
 		},
 		cursor: 0,
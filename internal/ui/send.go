@@ -1,37 +1,74 @@
 package ui
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/blocklist"
 	"github.com/SaneiyanReza/smsir-cli/internal/config"
+	"github.com/SaneiyanReza/smsir-cli/internal/contacts"
+	"github.com/SaneiyanReza/smsir-cli/internal/history"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/phone"
+	"github.com/SaneiyanReza/smsir-cli/internal/sms"
+	"github.com/SaneiyanReza/smsir-cli/internal/state"
 	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 // SendModel represents the send SMS model
 type SendModel struct {
-	client      *api.Client
-	config      *config.Config
-	messageText string
-	mobiles     string
-	lineNumber  string
-	quitting    bool
-	completed   bool
-	success     bool
-	result      *api.BulkSendResponse
-	err         error
-	width       int
-	height      int
-	step        int // 0: message, 1: mobiles, 2: line number (optional), 3: confirm
-}
-
-// Init initializes the send model
+	client     *api.Client
+	config     *config.Config
+	mobiles    string
+	lineNumber string
+	quitting   bool
+	completed  bool
+	success    bool
+	result     *api.BulkSendResponse
+	err        error
+	// historyErr tells why a sent message could not be saved to history
+	historyErr error
+	width      int
+	height     int
+	step       int // 0: message, 1: mobiles, 2: line number (optional), 3: confirm
+	linePicker LinePicker
+
+	// contacts picks recipients from the local address book; picking is set
+	// while it has the focus instead of the typed numbers
+	contacts ContactPicker
+	picking  bool
+	// blocklist flags blocklisted recipients before the confirm step; nil if it could not be loaded
+	blocklist *blocklist.Blocklist
+	// loadErrs tell why the contacts or the blocklist could not be loaded
+	loadErrs []string
+
+	// visited is the furthest step reached; those steps can be jumped back to
+	visited int
+	// stepErr tells why the current step could not be completed
+	stepErr string
+	// confirmingQuit is set while asking whether to throw away the unsent message
+	confirmingQuit bool
+
+	// editor is the multi-line message input; edits backs its undo and redo
+	editor textarea.Model
+	edits  editHistory
+
+	// credit is the account balance the projected cost is compared with
+	credit       float64
+	creditLoaded bool
+}
+
+// Init initializes the send model and starts loading the lines for the line step
 func (m SendModel) Init() tea.Cmd {
-	return nil
+	return tea.Batch(m.linePicker.Init(), textarea.Blink, loadCredit(m.client))
 }
 
 // Update handles messages
@@ -42,100 +79,122 @@ func (m SendModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case spinner.TickMsg, linesLoadedMsg:
+		var cmd tea.Cmd
+		m.linePicker, cmd = m.linePicker.Update(msg)
+		return m, cmd
+
+	case creditMsg:
+		m.credit = float64(msg)
+		m.creditLoaded = true
+		return m, nil
+
 	case tea.KeyMsg:
-		// Handle paste first
-		if msg.Type == tea.KeyCtrlV {
-			clipboardText, err := clipboard.ReadAll()
-			if err == nil && clipboardText != "" {
-				cleanText := strings.TrimSpace(strings.ReplaceAll(clipboardText, "\n", ""))
-				cleanText = strings.ReplaceAll(cleanText, "\r", "")
-
-				if m.step == 0 {
-					m.messageText = cleanText
-				} else if m.step == 1 {
-					m.mobiles = cleanText
-				} else if m.step == 2 {
-					m.lineNumber = cleanText
-				}
+		m.editor.SetWidth(m.editorWidth())
+
+		if m.confirmingQuit {
+			return m.answerQuit(msg)
+		}
+		if m.completed {
+			switch msg.String() {
+			case "ctrl+c", "esc", "q":
+				m.quitting = true
+				return m, tea.Quit
 			}
 			return m, nil
 		}
 
+		// Handle paste first
+		if msg.Type == tea.KeyCtrlV {
+			return m.paste()
+		}
+
 		switch msg.String() {
-		case "ctrl+c", "q", "esc":
-			m.quitting = true
-			return m, tea.Quit
+		case "ctrl+c":
+			return m.quit()
 
-		case "ctrl+v":
-			clipboardText, err := clipboard.ReadAll()
-			if err == nil && clipboardText != "" {
-				cleanText := strings.TrimSpace(strings.ReplaceAll(clipboardText, "\n", ""))
-				cleanText = strings.ReplaceAll(cleanText, "\r", "")
-
-				if m.step == 0 {
-					m.messageText = cleanText
-				} else if m.step == 1 {
-					m.mobiles = cleanText
-				} else if m.step == 2 {
-					m.lineNumber = cleanText
-				}
+		case "esc":
+			if m.step == 0 {
+				return m.quit()
+			}
+			return m.jumpTo(m.step - 1)
+
+		case "shift+tab":
+			if m.step > 0 {
+				return m.jumpTo(m.step - 1)
 			}
 			return m, nil
 
+		case "q":
+			// q is text in the message editor and the contact search
+			if m.step != 0 && !(m.step == 1 && m.picking) {
+				return m.quit()
+			}
+
+		case "ctrl+v":
+			return m.paste()
+
+		case "f2":
+			return m, toggleBidi()
+
 		case "enter":
 			if m.step == 3 {
 				// Send SMS
 				return m, m.sendSMS()
 			}
+			return m.next()
+		}
 
-			// Skip line number step if empty and config has line number
-			if m.step == 2 {
-				if m.lineNumber == "" && m.config.LineNumber != "" {
-					m.step++
-					return m, nil
-				}
+		// Any step can be edited again from the confirm step
+		if m.step == 3 {
+			if step, ok := stepKey(msg, 3); ok {
+				return m.jumpTo(step)
 			}
+			return m, nil
+		}
+
+		m.stepErr = ""
 
-			// Only advance if we have valid input
-			if m.step == 0 && m.messageText != "" {
-				m.step++
-			} else if m.step == 1 && m.mobiles != "" {
-				m.step++
-			} else if m.step == 2 {
-				m.step++
+		if m.step == 0 {
+			return m.updateEditor(msg)
+		}
+
+		if m.step == 2 {
+			var cmd tea.Cmd
+			m.linePicker, cmd = m.linePicker.Update(msg)
+			return m, cmd
+		}
+
+		if m.step == 1 {
+			if msg.String() == "tab" {
+				// Switch between typing numbers and picking contacts
+				if !m.contacts.Empty() {
+					m.picking = !m.picking
+				}
+				return m, nil
 			}
-			return m, nil
+			if m.picking {
+				var cmd tea.Cmd
+				m.contacts, cmd = m.contacts.Update(msg)
+				return m, cmd
+			}
+		}
 
+		switch msg.String() {
 		case "backspace":
-			if m.step == 0 && len(m.messageText) > 0 {
-				runes := []rune(m.messageText)
-				if len(runes) > 0 {
-					m.messageText = string(runes[:len(runes)-1])
-				}
-			} else if m.step == 1 && len(m.mobiles) > 0 {
+			if m.step == 1 && len(m.mobiles) > 0 {
 				runes := []rune(m.mobiles)
 				if len(runes) > 0 {
 					m.mobiles = string(runes[:len(runes)-1])
 				}
-			} else if m.step == 2 && len(m.lineNumber) > 0 {
-				runes := []rune(m.lineNumber)
-				if len(runes) > 0 {
-					m.lineNumber = string(runes[:len(runes)-1])
-				}
 			}
 			return m, nil
 
 		default:
 			if msg.Type == tea.KeyRunes {
 				text := msg.String()
-				if text != "" {
-					if m.step == 0 {
-						m.messageText += text
-					} else if m.step == 1 {
-						m.mobiles += text
-					} else if m.step == 2 {
-						m.lineNumber += text
-					}
+				if text != "" && m.step == 1 {
+					m.mobiles += text
 				}
 				return m, nil
 			}
@@ -145,6 +204,7 @@ func (m SendModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case sendSuccessMsg:
 		m.success = true
 		m.result = msg.result
+		m.historyErr = msg.historyErr
 		m.completed = true
 		return m, nil
 
@@ -154,14 +214,166 @@ func (m SendModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	default:
+		// Cursor blinking of the message editor
+		if m.step == 0 {
+			var cmd tea.Cmd
+			m.editor, cmd = m.editor.Update(msg)
+			return m, cmd
+		}
 		return m, nil
 	}
 }
 
+// next moves on from the current step when its input is valid. Once the
+// confirm step was reached, editing an earlier step leads straight back to it.
+func (m SendModel) next() (tea.Model, tea.Cmd) {
+	if m.step == 2 {
+		if m.linePicker.Loading() {
+			return m, nil
+		}
+		// An empty line falls back to the configured line number
+		m.lineNumber = m.linePicker.Value()
+	}
+
+	if err := m.validateStep(); err != nil {
+		m.stepErr = err.Error()
+		return m, nil
+	}
+
+	if m.visited == 3 {
+		return m.jumpTo(3)
+	}
+	return m.jumpTo(m.step + 1)
+}
+
+// validateStep checks the input of the current step
+func (m SendModel) validateStep() error {
+	switch m.step {
+	case 0:
+		if strings.TrimSpace(m.editor.Value()) == "" {
+			return i18n.Errorf("message is required")
+		}
+	case 1:
+		mobiles := m.recipients()
+		if len(mobiles) == 0 {
+			return i18n.Errorf("to mobiles is required")
+		}
+		for _, mobile := range mobiles {
+			if _, err := phone.Normalize(mobile); err != nil {
+				return err
+			}
+		}
+		if len(m.allowedRecipients()) == 0 {
+			return i18n.Errorf("all recipients are blocklisted")
+		}
+	case 2:
+		if _, err := m.resolveLine(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jumpTo shows a step, focusing the message editor on the first one
+func (m SendModel) jumpTo(step int) (tea.Model, tea.Cmd) {
+	m.step = step
+	m.visited = max(m.visited, step)
+	m.stepErr = ""
+	if step == 0 {
+		return m, m.editor.Focus()
+	}
+	m.editor.Blur()
+	return m, nil
+}
+
+// quit leaves the wizard, asking first when a typed message would be lost
+func (m SendModel) quit() (tea.Model, tea.Cmd) {
+	if strings.TrimSpace(m.editor.Value()) != "" || m.mobiles != "" || m.contacts.Count() > 0 {
+		m.confirmingQuit = true
+		return m, nil
+	}
+	m.quitting = true
+	return m, tea.Quit
+}
+
+// answerQuit handles the answer to the question asked by quit
+func (m SendModel) answerQuit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y", "ctrl+c":
+		m.quitting = true
+		return m, tea.Quit
+	case "n", "N", "esc":
+		m.confirmingQuit = false
+	}
+	return m, nil
+}
+
+// paste inserts the clipboard text. The message editor keeps its line breaks;
+// the single-line inputs get them removed.
+func (m SendModel) paste() (tea.Model, tea.Cmd) {
+	clipboardText, err := clipboard.ReadAll()
+	if err != nil || clipboardText == "" {
+		return m, nil
+	}
+
+	if m.step == 0 {
+		before := m.editor.Value()
+		// The editor would turn the \r of a Windows line break into a second line
+		m.editor.InsertString(strings.ReplaceAll(clipboardText, "\r\n", "\n"))
+		m.edits.record(before, false)
+		return m, nil
+	}
+
+	cleanText := strings.TrimSpace(strings.ReplaceAll(clipboardText, "\n", ""))
+	cleanText = strings.ReplaceAll(cleanText, "\r", "")
+	if m.step == 1 {
+		m.mobiles = cleanText
+		m.picking = false
+	} else if m.step == 2 {
+		m.linePicker.SetInput(cleanText)
+	}
+	return m, nil
+}
+
+// updateEditor passes a key to the message editor, recording edits for undo
+func (m SendModel) updateEditor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+z":
+		if value, ok := m.edits.Undo(m.editor.Value()); ok {
+			m.editor.SetValue(value)
+		}
+		return m, nil
+	case "ctrl+y":
+		if value, ok := m.edits.Redo(m.editor.Value()); ok {
+			m.editor.SetValue(value)
+		}
+		return m, nil
+	}
+
+	before := m.editor.Value()
+	var cmd tea.Cmd
+	m.editor, cmd = m.editor.Update(msg)
+	if m.editor.Value() != before {
+		m.edits.record(before, msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace)
+	} else {
+		m.edits.breakTyping()
+	}
+	return m, cmd
+}
+
+// editorWidth is the width of the message editor inside the content box
+func (m SendModel) editorWidth() int {
+	// The box has a border and a horizontal padding of 2 on each side
+	if w := m.width - 8; w > 20 {
+		return w
+	}
+	return defaultEditorWidth
+}
+
 // View renders the send interface
 func (m SendModel) View() string {
 	if m.quitting && !m.completed {
-		return "SMS sending cancelled.\n"
+		return i18n.T("SMS sending cancelled.\n")
 	}
 
 	if m.completed {
@@ -185,6 +397,11 @@ func (m SendModel) View() string {
 	s.WriteString(content)
 	s.WriteString("\n\n")
 
+	if m.confirmingQuit {
+		s.WriteString(renderQuitPrompt(i18n.T("Discard the unsent message?")))
+		return s.String()
+	}
+
 	instructions := m.renderInstructions()
 	s.WriteString(instructions)
 
@@ -198,28 +415,14 @@ func (m SendModel) renderHeader() string {
 		Foreground(lipgloss.Color("#f7bd60")).
 		Align(lipgloss.Center)
 
-	title := "📤 Send SMS"
-	return titleStyle.Render(title)
+	title := i18n.T("📤 Send SMS")
+	return titleStyle.Render(bidiLine(title))
 }
 
 // renderProgress renders progress indicator
 func (m SendModel) renderProgress() string {
-	steps := []string{"Message", "Mobiles", "Line Number", "Confirm"}
-
-	var progress []string
-	for i, step := range steps {
-		if i <= m.step {
-			progress = append(progress, fmt.Sprintf("✓ %s", step))
-		} else {
-			progress = append(progress, fmt.Sprintf("○ %s", step))
-		}
-	}
-
-	progressStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#9CA3AF")).
-		Align(lipgloss.Center)
-
-	return progressStyle.Render(strings.Join(progress, " → "))
+	steps := []string{i18n.T("Message"), i18n.T("Mobiles"), i18n.T("Line Number"), i18n.T("Confirm")}
+	return renderSteps(steps, m.step, m.visited)
 }
 
 // renderContent renders the main content
@@ -242,6 +445,9 @@ func (m SendModel) renderContent() string {
 	case 3:
 		content = m.renderConfirmStep()
 	}
+	if m.stepErr != "" {
+		content += "\n\n" + renderStepError(m.stepErr)
+	}
 
 	return boxStyle.Render(content)
 }
@@ -252,23 +458,166 @@ func (m SendModel) renderMessageStep() string {
 		Bold(true).
 		Foreground(lipgloss.Color("#f7bd60"))
 
-	inputStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff")).
+	title := i18n.T("Enter your message text:")
+
+	editor := m.editor
+	editor.SetWidth(m.editorWidth())
+
+	content := titleStyle.Render(bidiLine(title)) + "\n\n" + editor.View()
+	if preview := m.renderBidiPreview(); preview != "" {
+		content += "\n\n" + preview
+	}
+	return content + "\n\n" + m.renderMessageStatus()
+}
+
+// renderBidiPreview renders the message in visual order when the editor
+// holds right-to-left text. The editor itself shows the logical order, since
+// its cursor moves through the text as stored.
+func (m SendModel) renderBidiPreview() string {
+	text := m.editor.Value()
+	if !BidiEnabled() || !hasRTL(text) {
+		return ""
+	}
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff"))
+
+	return mutedStyle.Render(bidiLine(i18n.T("Preview:"))) + "\n" + textStyle.Render(renderBidi(text, m.editorWidth()))
+}
+
+// renderMessageStatus renders the length, encoding and parts of the message
+// as it will be sent, i.e. with the line's signature
+func (m SendModel) renderMessageStatus() string {
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	highlightStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B")).
 		Bold(true)
 
-	placeholderStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#9CA3AF")).
-		Italic(true)
+	text, signed := m.signedMessage()
+	info := sms.Count(text)
 
-	title := "Enter your message text:"
-	var input string
-	if m.messageText == "" {
-		input = placeholderStyle.Render("Type here or press Ctrl+V to paste...")
-	} else {
-		input = inputStyle.Render(m.messageText)
+	status := i18n.Sprintf("📏 %d chars • %s • %d part(s) • %d left in this part",
+		utf8.RuneCountInString(text), info.Encoding, info.Segments, info.Remaining)
+	if signed {
+		status += i18n.T(" (incl. signature)")
+	}
+	status = mutedStyle.Render(bidiLine(status))
+
+	// Point out the few characters that make mostly Latin text Unicode,
+	// e.g. smart quotes, which more than double the number of parts
+	chars := sms.UnicodeChars(m.editor.Value())
+	if len(chars) > 0 && len(chars) <= maxHighlightedChars {
+		highlighted := make([]string, len(chars))
+		for i, r := range chars {
+			highlighted[i] = highlightStyle.Render(strconv.QuoteRune(r))
+		}
+		status += "\n" + mutedStyle.Render(bidiLine(i18n.T("Unicode because of: "))) + strings.Join(highlighted, " ")
+	}
+
+	return status
+}
+
+// maxHighlightedChars is the most characters renderMessageStatus points out;
+// text with more, such as Persian, is expected to be Unicode
+const maxHighlightedChars = 5
+
+// renderCostPreview renders the projected cost of the message for the entered
+// recipients, leaving out blocklisted ones, and compares it with the account's credit
+func (m SendModel) renderCostPreview() string {
+	recipients := len(m.allowedRecipients())
+	if recipients == 0 {
+		return ""
+	}
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B"))
+
+	text, _ := m.signedMessage()
+	parts := sms.Segments(text)
+	cost := float64(parts * recipients)
+
+	preview := i18n.Sprintf("💰 Projected cost: %s SMS (%d part(s) × %d recipient(s))",
+		i18n.Digits(strconv.FormatFloat(cost, 'f', -1, 64)), parts, recipients)
+	if !m.creditLoaded {
+		return mutedStyle.Render(bidiLine(preview))
+	}
+	if cost > m.credit {
+		return errorStyle.Render(bidiLine(i18n.Sprintf("%s exceeds your credit of %.2f SMS", preview, m.credit)))
+	}
+	return mutedStyle.Render(bidiLine(i18n.Sprintf("%s of %.2f SMS credit", preview, m.credit)))
+}
+
+// signedMessage returns the message as it will be sent and whether a signature was added
+func (m SendModel) signedMessage() (string, bool) {
+	text := m.editor.Value()
+	line, err := m.resolveLine()
+	if err != nil {
+		return text, false
 	}
+	signed := line.Sign(text)
+	return signed, signed != text
+}
 
-	return titleStyle.Render(title) + "\n\n" + input
+// recipients returns the typed mobile numbers followed by the picked
+// contacts, without numbers that were entered twice
+func (m SendModel) recipients() []string {
+	typed := strings.Split(m.mobiles, ",")
+	seen := make(map[string]bool)
+	var mobiles []string
+	for _, mobile := range append(typed, m.contacts.Mobiles()...) {
+		if mobile = strings.TrimSpace(mobile); mobile == "" {
+			continue
+		}
+		key := mobile
+		if number, err := phone.Normalize(mobile); err == nil {
+			key = number
+		}
+		if !seen[key] {
+			seen[key] = true
+			mobiles = append(mobiles, mobile)
+		}
+	}
+	return mobiles
+}
+
+// allowedRecipients returns the recipients that are not blocklisted
+func (m SendModel) allowedRecipients() []string {
+	if m.blocklist == nil {
+		return m.recipients()
+	}
+	allowed, _ := m.blocklist.Filter(m.recipients())
+	return allowed
+}
+
+// renderRecipientProblems lists the recipients that are invalid or will be
+// skipped because they are blocklisted
+func (m SendModel) renderRecipientProblems() string {
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B"))
+
+	var problems []string
+	for _, mobile := range m.recipients() {
+		if _, err := phone.Normalize(mobile); err != nil {
+			problems = append(problems, errorStyle.Render(bidiLine("❌ "+err.Error())))
+			continue
+		}
+		label := mobile
+		if name := m.contacts.Name(mobile); name != "" {
+			label = name + " " + mobile
+		}
+		if m.blocklist != nil && m.blocklist.Contains(mobile) {
+			problems = append(problems, errorStyle.Render(bidiLine(i18n.Sprintf("⛔ %s: blocklisted, will be skipped", label))))
+		}
+	}
+	return strings.Join(problems, "\n")
 }
 
 // renderMobilesStep renders the mobiles input step
@@ -285,45 +634,50 @@ func (m SendModel) renderMobilesStep() string {
 		Foreground(lipgloss.Color("#9CA3AF")).
 		Italic(true)
 
-	title := "Enter mobile numbers (comma-separated):"
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	title := i18n.T("Enter mobile numbers (comma-separated):")
 	var input string
-	if m.mobiles == "" {
+	if m.picking {
+		title = i18n.T("Pick contacts and groups:")
+		input = m.contacts.View()
+	} else if m.mobiles == "" {
 		input = placeholderStyle.Render("e.g., 09120000000,09121111111")
 	} else {
 		input = inputStyle.Render(m.mobiles)
 	}
 
-	return titleStyle.Render(title) + "\n\n" + input
+	selected := i18n.Sprintf("👥 %d selected • %d recipient(s)", m.contacts.Count(), len(m.recipients()))
+	content := titleStyle.Render(bidiLine(title)) + "\n\n" + input + "\n\n" + mutedStyle.Render(bidiLine(selected))
+	for _, loadErr := range m.loadErrs {
+		content += "\n" + renderStepError(loadErr)
+	}
+	if problems := m.renderRecipientProblems(); problems != "" {
+		content += "\n" + problems
+	}
+	content += "\n\n" + m.renderMessageStatus()
+	if preview := m.renderCostPreview(); preview != "" {
+		content += "\n" + preview
+	}
+	return content
 }
 
-// renderLineNumberStep renders the line number input step
+// renderLineNumberStep renders the line picker step
 func (m SendModel) renderLineNumberStep() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("#f7bd60"))
 
-	inputStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff")).
-		Bold(true)
-
-	placeholderStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#9CA3AF")).
-		Italic(true)
-
-	defaultLine := ""
-	if m.config.LineNumber != "" {
-		defaultLine = fmt.Sprintf(" (Press Enter to use: %s)", m.config.LineNumber)
-	}
-
-	title := fmt.Sprintf("Enter line number%s:", defaultLine)
-	var input string
-	if m.lineNumber == "" {
-		input = placeholderStyle.Render("Leave empty to use configured line number")
-	} else {
-		input = inputStyle.Render(m.lineNumber)
+	title := i18n.T("Choose the sender line:")
+	if m.linePicker.Manual() {
+		title = i18n.T("Enter line number:")
+		if m.config.LineNumber != "" {
+			title = i18n.Sprintf("Enter line number (leave empty to use %s):", m.config.LineNumber)
+		}
 	}
 
-	return titleStyle.Render(title) + "\n\n" + input
+	return titleStyle.Render(bidiLine(title)) + "\n\n" + m.linePicker.View()
 }
 
 // renderConfirmStep renders the confirmation step
@@ -335,28 +689,60 @@ func (m SendModel) renderConfirmStep() string {
 	infoStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#ffffff"))
 
-	lineNumber := m.lineNumber
-	if lineNumber == "" {
-		lineNumber = m.config.LineNumber
-		if lineNumber == "" {
-			lineNumber = "Not set"
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B"))
+
+	mobilesList := m.allowedRecipients()
+	skipped := len(m.recipients()) - len(mobilesList)
+
+	title := i18n.T("Confirm and Send:")
+	messageText := m.editor.Value()
+	var line string
+	var notes []string
+	if resolved, err := m.resolveLine(); err != nil {
+		line = i18n.T("Line Number: Not set")
+		notes = append(notes, errorStyle.Render(bidiLine("❌ "+err.Error())))
+	} else {
+		messageText = resolved.Sign(messageText)
+		line = i18n.Sprintf("Line Number: %s", resolved.Label())
+		if err := resolved.CheckHours(time.Now()); err != nil {
+			notes = append(notes, errorStyle.Render(bidiLine("🕒 "+err.Error())))
+		}
+		if resolved.Tariff > 0 {
+			notes = append(notes, mutedStyle.Render(bidiLine(i18n.Sprintf("💡 Estimated cost: %s (%d part(s) × %d recipient(s))",
+				i18n.Digits(strconv.FormatFloat(sms.EstimateCost(messageText, len(mobilesList), resolved.Tariff), 'f', -1, 64)),
+				sms.Segments(messageText), len(mobilesList)))))
 		}
 	}
-
-	mobilesList := strings.Split(m.mobiles, ",")
-	for i := range mobilesList {
-		mobilesList[i] = strings.TrimSpace(mobilesList[i])
+	if preview := m.renderCostPreview(); preview != "" {
+		notes = append(notes, preview)
 	}
+	if skipped > 0 {
+		notes = append(notes, mutedStyle.Render(bidiLine(i18n.Sprintf("⛔ %d blocklisted number(s) will be skipped", skipped))))
+	}
+	message := bidiLine(i18n.T("Message:")) + "\n" + renderBidi(messageText, m.editorWidth())
+	mobiles := bidiLine(i18n.Sprintf("Mobiles: %s", strings.Join(mobilesList, ", ")))
 
-	title := "Confirm and Send:"
-	message := fmt.Sprintf("Message: %s", m.messageText)
-	mobiles := fmt.Sprintf("Mobiles: %s", strings.Join(mobilesList, ", "))
-	line := fmt.Sprintf("Line Number: %s", lineNumber)
-
-	return titleStyle.Render(title) + "\n\n" +
+	content := titleStyle.Render(bidiLine(title)) + "\n\n" +
 		infoStyle.Render(message) + "\n" +
 		infoStyle.Render(mobiles) + "\n" +
-		infoStyle.Render(line)
+		infoStyle.Render(bidiLine(line))
+	if len(notes) > 0 {
+		content += "\n\n" + strings.Join(notes, "\n")
+	}
+	return content
+}
+
+// resolveLine returns the chosen line, falling back to the configured one
+func (m SendModel) resolveLine() (config.Line, error) {
+	lineNumber := m.lineNumber
+	if lineNumber == "" {
+		lineNumber = m.config.LineNumber
+	}
+	return m.config.ResolveLine(lineNumber)
 }
 
 // renderInstructions renders instructions
@@ -366,20 +752,59 @@ func (m SendModel) renderInstructions() string {
 		Align(lipgloss.Center)
 
 	var instructions []string
-	if m.step < 3 {
+	if m.step == 0 {
+		instructions = []string{
+			i18n.T("Enter: continue"),
+			i18n.T("Alt+Enter: new line"),
+			i18n.T("Ctrl+Z/Ctrl+Y: undo/redo"),
+			i18n.T("Ctrl+V: paste"),
+			i18n.Sprintf("F2: %s", bidiToggleLabel()),
+			i18n.T("Esc or Ctrl+C: cancel"),
+		}
+	} else if m.step == 1 && m.picking {
 		instructions = []string{
-			"Type your information and press Enter to continue",
-			"Press Ctrl+V to paste from clipboard",
-			"Press q or Ctrl+C to cancel",
+			i18n.T("Type to search"),
+			i18n.T("↑/↓: move"),
+			i18n.T("Space: select"),
+			i18n.T("Tab: type numbers"),
+			i18n.T("Enter: continue"),
+			i18n.T("Esc or Shift+Tab: back"),
+			i18n.T("Ctrl+C: cancel"),
+		}
+	} else if m.step == 1 && !m.contacts.Empty() {
+		instructions = []string{
+			i18n.T("Type your information and press Enter to continue"),
+			i18n.T("Tab: pick from contacts"),
+			i18n.T("Esc or Shift+Tab: back"),
+			i18n.T("Press Ctrl+V to paste from clipboard"),
+			i18n.T("Press q or Ctrl+C to cancel"),
+		}
+	} else if m.step < 3 {
+		instructions = []string{
+			i18n.T("Type your information and press Enter to continue"),
+			i18n.T("Esc or Shift+Tab: back"),
+			i18n.T("Press Ctrl+V to paste from clipboard"),
+			i18n.T("Press q or Ctrl+C to cancel"),
 		}
 	} else {
 		instructions = []string{
-			"Press Enter to send SMS",
-			"Press q or Ctrl+C to cancel",
+			i18n.T("Press Enter to send SMS"),
+			i18n.T("1-3: edit a step"),
+			i18n.T("Esc or Shift+Tab: back"),
+			i18n.Sprintf("Press F2 to %s", bidiToggleLabel()),
+			i18n.T("Press q or Ctrl+C to cancel"),
 		}
 	}
 
-	return instructionStyle.Render(strings.Join(instructions, " • "))
+	return instructionStyle.Render(bidiLine(strings.Join(instructions, " • ")))
+}
+
+// bidiToggleLabel describes what F2 does
+func bidiToggleLabel() string {
+	if BidiEnabled() {
+		return i18n.T("leave RTL text to the terminal")
+	}
+	return i18n.T("reorder RTL text")
 }
 
 // renderSuccess renders success message
@@ -397,12 +822,20 @@ func (m SendModel) renderSuccess() string {
 	infoStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#ffffff"))
 
-	content := titleStyle.Render("✅ SMS sent successfully!") + "\n\n" +
-		infoStyle.Render(fmt.Sprintf("📦 Pack ID: %s", m.result.PackID)) + "\n" +
-		infoStyle.Render(fmt.Sprintf("💰 Cost: %.2f SMS", m.result.Cost)) + "\n" +
-		infoStyle.Render(fmt.Sprintf("📱 Message IDs: %v", m.result.MessageIds)) + "\n" +
-		infoStyle.Render(fmt.Sprintf("📊 Total messages: %d", len(m.result.MessageIds))) + "\n\n" +
-		infoStyle.Render("Press q or Ctrl+C to exit...")
+	message, _ := m.signedMessage()
+
+	content := titleStyle.Render(bidiLine(i18n.T("✅ SMS sent successfully!"))) + "\n\n" +
+		infoStyle.Render(bidiLine(i18n.T("💬 Message:"))+"\n"+renderBidi(message, m.editorWidth())) + "\n" +
+		infoStyle.Render(bidiLine(i18n.Sprintf("📦 Pack ID: %s", m.result.PackID))) + "\n" +
+		infoStyle.Render(bidiLine(i18n.Sprintf("💰 Cost: %.2f SMS", m.result.Cost))) + "\n" +
+		infoStyle.Render(bidiLine(i18n.Sprintf("📱 Message IDs: %v", m.result.MessageIds))) + "\n" +
+		infoStyle.Render(bidiLine(i18n.Sprintf("📊 Total messages: %d", len(m.result.MessageIds)))) + "\n\n"
+	if m.historyErr != nil {
+		warningStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF6B6B"))
+		content += warningStyle.Render(bidiLine(i18n.Sprintf("⚠️  Could not save to history: %v", m.historyErr))) + "\n\n"
+	}
+	content += infoStyle.Render(bidiLine(i18n.T("Press q or Ctrl+C to exit...")))
 
 	return boxStyle.Render(content)
 }
@@ -422,16 +855,17 @@ func (m SendModel) renderError() string {
 	errorStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#ffffff"))
 
-	content := titleStyle.Render("❌ Error sending SMS") + "\n\n" +
-		errorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n\n" +
-		errorStyle.Render("Press q or Ctrl+C to exit...")
+	content := titleStyle.Render(bidiLine(i18n.T("❌ Error sending SMS"))) + "\n\n" +
+		errorStyle.Render(bidiLine(i18n.Sprintf("Error: %v", m.err))) + "\n\n" +
+		errorStyle.Render(bidiLine(i18n.T("Press q or Ctrl+C to exit...")))
 
 	return boxStyle.Render(content)
 }
 
 // Messages
 type sendSuccessMsg struct {
-	result *api.BulkSendResponse
+	result     *api.BulkSendResponse
+	historyErr error
 }
 
 type sendErrorMsg struct {
@@ -441,28 +875,33 @@ type sendErrorMsg struct {
 // sendSMS sends the SMS
 func (m SendModel) sendSMS() tea.Cmd {
 	return func() tea.Msg {
-		mobilesList := strings.Split(m.mobiles, ",")
-		for i := range mobilesList {
-			mobilesList[i] = strings.TrimSpace(mobilesList[i])
-		}
+		mobilesList := m.recipients()
 
-		lineNumberStr := m.lineNumber
-		if lineNumberStr == "" {
-			lineNumberStr = m.config.LineNumber
+		bl, err := blocklist.Load()
+		if err != nil {
+			return sendErrorMsg{err: i18n.Errorf("error loading blocklist: %w", err)}
 		}
-
-		if lineNumberStr == "" {
-			return sendErrorMsg{err: fmt.Errorf("line number is required")}
+		mobilesList, _ = bl.Filter(mobilesList)
+		if len(mobilesList) == 0 {
+			return sendErrorMsg{err: i18n.Errorf("all recipients are blocklisted")}
 		}
 
-		lineNumber, err := strconv.ParseInt(lineNumberStr, 10, 64)
+		line, err := m.resolveLine()
 		if err != nil {
-			return sendErrorMsg{err: fmt.Errorf("invalid line number: %w", err)}
+			return sendErrorMsg{err: err}
+		}
+		lineNumber, err := line.Int()
+		if err != nil {
+			return sendErrorMsg{err: err}
 		}
+		if err := line.CheckHours(time.Now()); err != nil {
+			return sendErrorMsg{err: err}
+		}
+		messageText := line.Sign(m.editor.Value())
 
 		req := api.BulkSendRequest{
 			LineNumber:  lineNumber,
-			MessageText: m.messageText,
+			MessageText: messageText,
 			Mobiles:     mobilesList,
 		}
 
@@ -472,18 +911,77 @@ func (m SendModel) sendSMS() tea.Cmd {
 		}
 
 		if !resp.IsSuccess() {
-			return sendErrorMsg{err: fmt.Errorf("API error: %s", resp.GetStatusMessage())}
+			return sendErrorMsg{err: i18n.Errorf("API error: %s", resp.GetStatusMessage())}
 		}
 
-		return sendSuccessMsg{result: &resp.Data}
+		// The last used line is only a default for the next send
+		_ = state.SetLastLine(m.config.Profile, line.Number)
+
+		historyErr := history.Save(&history.Record{
+			Profile:     m.config.Profile,
+			LineNumber:  lineNumber,
+			MessageText: messageText,
+			Recipients:  mobilesList,
+			PackID:      resp.Data.PackID,
+			MessageIDs:  resp.Data.MessageIds,
+			Cost:        resp.Data.Cost,
+			Origin:      history.OriginTUI,
+		})
+
+		return sendSuccessMsg{result: &resp.Data, historyErr: historyErr}
 	}
 }
 
 // NewSendModel creates a new send model
 func NewSendModel(client *api.Client, cfg *config.Config) SendModel {
+	// Without them the numbers can still be typed; the errors are shown on the
+	// mobiles step, and sendSMS loads the blocklist again before sending
+	var loadErrs []string
+	book, err := contacts.Load()
+	if err != nil {
+		loadErrs = append(loadErrs, i18n.Sprintf("Could not load contacts: %v", err))
+	}
+	bl, err := blocklist.Load()
+	if err != nil {
+		loadErrs = append(loadErrs, i18n.Sprintf("Could not load the blocklist: %v", err))
+	}
+
 	return SendModel{
-		client: client,
-		config: cfg,
-		step:   0,
+		client:     client,
+		config:     cfg,
+		step:       0,
+		linePicker: NewLinePicker(client, cfg, state.LastLine(cfg.Profile)),
+		contacts:   NewContactPicker(book),
+		blocklist:  bl,
+		loadErrs:   loadErrs,
+		editor:     newMessageEditor(),
 	}
 }
+
+// defaultEditorWidth is used until the terminal size is known
+const defaultEditorWidth = 60
+
+// newMessageEditor creates the multi-line message input. Enter moves on to
+// the next step, so a new line is inserted with Alt+Enter.
+func newMessageEditor() textarea.Model {
+	editor := textarea.New()
+	editor.Placeholder = i18n.T("Type here or press Ctrl+V to paste...")
+	editor.Prompt = ""
+	editor.ShowLineNumbers = false
+	editor.CharLimit = 0
+	editor.SetHeight(5)
+	editor.SetWidth(defaultEditorWidth)
+	editor.KeyMap.InsertNewline = key.NewBinding(key.WithKeys("alt+enter"))
+
+	editor.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	editor.FocusedStyle.Text = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff")).
+		Bold(true)
+	editor.FocusedStyle.Placeholder = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Italic(true)
+	editor.BlurredStyle = editor.FocusedStyle
+
+	editor.Focus()
+	return editor
+}
@@ -0,0 +1,39 @@
+package ui
+
+import "strings"
+
+// sparkBlocks are the bars of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws values as a row of bars scaled between their minimum and
+// maximum. With more values than width, each bar shows the last value of
+// its share of the values.
+func sparkline(values []float64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+
+	if len(values) > width {
+		sampled := make([]float64, width)
+		for i := range sampled {
+			sampled[i] = values[(i+1)*len(values)/width-1]
+		}
+		values = sampled
+	}
+
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low = min(low, v)
+		high = max(high, v)
+	}
+
+	var s strings.Builder
+	for _, v := range values {
+		level := len(sparkBlocks) / 2
+		if high > low {
+			level = int((v - low) / (high - low) * float64(len(sparkBlocks)-1))
+		}
+		s.WriteRune(sparkBlocks[level])
+	}
+	return s.String()
+}
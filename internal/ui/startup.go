@@ -1,10 +1,10 @@
 package ui
 
 import (
-	"fmt"
 	"strings"
 	"time"
 
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -57,7 +57,7 @@ func (m StartupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View renders the startup animation
 func (m StartupModel) View() string {
 	if m.quitting {
-		return "Goodbye! 👋\n"
+		return i18n.T("Goodbye! 👋\n")
 	}
 
 	var s strings.Builder
@@ -109,9 +109,9 @@ func (m StartupModel) renderTagline() string {
 		Align(lipgloss.Center).
 		Italic(true)
 
-	tagline := "A simple message can connect worlds with a single command"
+	tagline := i18n.T("A simple message can connect worlds with a single command")
 
-	return taglineStyle.Render(tagline)
+	return taglineStyle.Render(bidiLine(tagline))
 }
 
 // renderProgress renders the progress bar
@@ -124,7 +124,7 @@ func (m StartupModel) renderProgress() string {
 		Align(lipgloss.Center)
 
 	bar := "[" + strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled) + "]"
-	percentage := fmt.Sprintf(" %d%%", m.progress)
+	percentage := i18n.Sprintf(" %d%%", m.progress)
 
 	return barStyle.Render(bar + percentage)
 }
@@ -136,10 +136,10 @@ func (m StartupModel) renderLoading() string {
 		Align(lipgloss.Center)
 
 	loadingTexts := []string{
-		"Loading...",
-		"Connecting to SMS.ir...",
-		"Preparing user interface...",
-		"Almost ready!",
+		i18n.T("Loading..."),
+		i18n.T("Connecting to SMS.ir..."),
+		i18n.T("Preparing user interface..."),
+		i18n.T("Almost ready!"),
 	}
 
 	textIndex := (m.progress / 25) % len(loadingTexts)
@@ -147,7 +147,7 @@ func (m StartupModel) renderLoading() string {
 		textIndex = len(loadingTexts) - 1
 	}
 
-	return loadingStyle.Render(loadingTexts[textIndex])
+	return loadingStyle.Render(bidiLine(loadingTexts[textIndex]))
 }
 
 // Messages
@@ -0,0 +1,56 @@
+package ui
+
+// maxUndo is the number of edits that can be undone
+const maxUndo = 100
+
+// editHistory keeps the earlier and undone values of a text editor.
+// Consecutive typing is recorded as a single edit, so undo removes a
+// whole burst of typing rather than one character at a time.
+type editHistory struct {
+	undo   []string
+	redo   []string
+	typing bool
+}
+
+// record saves the value from before an edit; typing marks plain character input
+func (h *editHistory) record(before string, typing bool) {
+	if typing && h.typing {
+		return
+	}
+	h.typing = typing
+
+	h.undo = append(h.undo, before)
+	if len(h.undo) > maxUndo {
+		h.undo = h.undo[1:]
+	}
+	h.redo = nil
+}
+
+// breakTyping ends the current burst of typing, e.g. after the cursor moved
+func (h *editHistory) breakTyping() {
+	h.typing = false
+}
+
+// Undo returns the value before the last edit and whether there was one
+func (h *editHistory) Undo(current string) (string, bool) {
+	if len(h.undo) == 0 {
+		return current, false
+	}
+	value := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, current)
+	h.typing = false
+	return value, true
+}
+
+// Redo returns the value the last undo reverted and whether there was one
+func (h *editHistory) Redo(current string) (string, bool) {
+	if len(h.redo) == 0 {
+		return current, false
+	}
+	value := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, current)
+	h.typing = false
+	return value, true
+}
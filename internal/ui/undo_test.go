@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"strconv"
+	"testing"
+)
+
+// editStep is one action on an editor whose undo is kept by editHistory.
+// type and edit change the value to value; undo and redo expect it to become
+// value and report ok.
+type editStep struct {
+	action string
+	value  string
+	ok     bool
+}
+
+func TestEditHistory(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []editStep
+	}{
+		{
+			name: "a burst of typing is undone at once",
+			steps: []editStep{
+				{action: "type", value: "h"},
+				{action: "type", value: "hi"},
+				{action: "undo", value: "", ok: true},
+				{action: "undo", value: "", ok: false},
+			},
+		},
+		{
+			name: "a cursor move ends the burst",
+			steps: []editStep{
+				{action: "type", value: "h"},
+				{action: "break"},
+				{action: "type", value: "hi"},
+				{action: "undo", value: "h", ok: true},
+				{action: "undo", value: "", ok: true},
+			},
+		},
+		{
+			name: "other edits are undone one by one",
+			steps: []editStep{
+				{action: "type", value: "hi"},
+				{action: "edit", value: "hi there"},
+				{action: "edit", value: "hi there!"},
+				{action: "undo", value: "hi there", ok: true},
+				{action: "undo", value: "hi", ok: true},
+				{action: "undo", value: "", ok: true},
+			},
+		},
+		{
+			name: "redo reverts undo",
+			steps: []editStep{
+				{action: "type", value: "hi"},
+				{action: "edit", value: ""},
+				{action: "undo", value: "hi", ok: true},
+				{action: "redo", value: "", ok: true},
+				{action: "redo", value: "", ok: false},
+				{action: "undo", value: "hi", ok: true},
+			},
+		},
+		{
+			name: "a new edit clears redo",
+			steps: []editStep{
+				{action: "type", value: "hi"},
+				{action: "undo", value: "", ok: true},
+				{action: "type", value: "yo"},
+				{action: "redo", value: "yo", ok: false},
+			},
+		},
+		{
+			name: "typing after undo starts a new burst",
+			steps: []editStep{
+				{action: "type", value: "hi"},
+				{action: "edit", value: "hi!"},
+				{action: "undo", value: "hi", ok: true},
+				{action: "type", value: "his"},
+				{action: "undo", value: "hi", ok: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var h editHistory
+			current := ""
+			for i, step := range tt.steps {
+				switch step.action {
+				case "type", "edit":
+					h.record(current, step.action == "type")
+					current = step.value
+				case "break":
+					h.breakTyping()
+				case "undo", "redo":
+					var ok bool
+					if step.action == "undo" {
+						current, ok = h.Undo(current)
+					} else {
+						current, ok = h.Redo(current)
+					}
+					if current != step.value || ok != step.ok {
+						t.Fatalf("step %d: %s = %q, %v, want %q, %v", i, step.action, current, ok, step.value, step.ok)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEditHistoryLimit(t *testing.T) {
+	var h editHistory
+	for i := 0; i < maxUndo+10; i++ {
+		h.record(strconv.Itoa(i), false)
+	}
+
+	current := "last"
+	undone := 0
+	for {
+		value, ok := h.Undo(current)
+		if !ok {
+			break
+		}
+		current = value
+		undone++
+	}
+	if undone != maxUndo {
+		t.Errorf("undid %d edits, want %d", undone, maxUndo)
+	}
+	if current != "10" {
+		t.Errorf("oldest kept value = %q, want %q", current, "10")
+	}
+}
@@ -0,0 +1,238 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	"github.com/SaneiyanReza/smsir-cli/internal/watch"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WatchModel shows live delivery progress of a sent pack
+type WatchModel struct {
+	client    *api.Client
+	packID    string
+	poller    *watch.Poller
+	deadline  time.Time
+	summary   api.DeliverySummary
+	polls     int
+	nextPoll  time.Duration
+	updatedAt time.Time
+	quitting  bool
+	completed bool
+	timedOut  bool
+	err       error
+	width     int
+	height    int
+}
+
+// Init initializes the watch model
+func (m WatchModel) Init() tea.Cmd {
+	return loadPackReport(m.client, m.packID)
+}
+
+// Update handles messages
+func (m WatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			m.quitting = true
+			return m, tea.Quit
+		case "f2":
+			return m, toggleBidi()
+		}
+		return m, nil
+
+	case packReportMsg:
+		m.summary = msg.summary
+		m.polls++
+		m.updatedAt = time.Now()
+
+		if m.summary.Done() {
+			m.completed = true
+			return m, tea.Quit
+		}
+
+		if !time.Now().Before(m.deadline) {
+			m.timedOut = true
+			return m, tea.Quit
+		}
+		// The last poll happens at the deadline rather than one interval before it
+		m.nextPoll = min(m.poller.Next(m.summary), time.Until(m.deadline))
+
+		client, packID := m.client, m.packID
+		return m, tea.Tick(m.nextPoll, func(time.Time) tea.Msg {
+			return loadPackReport(client, packID)()
+		})
+
+	case errMsg:
+		m.err = msg
+		return m, tea.Quit
+
+	default:
+		return m, nil
+	}
+}
+
+// View renders the watch interface
+func (m WatchModel) View() string {
+	var s strings.Builder
+
+	header := m.renderHeader()
+	s.WriteString(header)
+	s.WriteString("\n\n")
+
+	content := m.renderContent()
+	s.WriteString(content)
+	s.WriteString("\n\n")
+
+	instructions := m.renderInstructions()
+	s.WriteString(instructions)
+	s.WriteString("\n")
+
+	return s.String()
+}
+
+// renderHeader renders the header
+func (m WatchModel) renderHeader() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#f7bd60")).
+		Align(lipgloss.Center)
+
+	return titleStyle.Render(bidiLine(i18n.Sprintf("📡 Delivery Watch • Pack %s", m.packID)))
+}
+
+// renderContent renders the progress box
+func (m WatchModel) renderContent() string {
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#f7bd60")).
+		Padding(1, 2)
+	if m.width > 4 {
+		boxStyle = boxStyle.Width(m.width - 4)
+	}
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff"))
+
+	if m.err != nil {
+		return boxStyle.BorderForeground(lipgloss.Color("#FF6B6B")).
+			Render(infoStyle.Render(bidiLine(i18n.Sprintf("❌ Error: %v", m.err))))
+	}
+
+	if m.polls == 0 {
+		return boxStyle.Render(infoStyle.Render(bidiLine(i18n.T("Loading delivery report... ⏳"))))
+	}
+
+	status := i18n.Sprintf("Next check in %s", m.nextPoll.Round(time.Second))
+	switch {
+	case m.completed:
+		status = i18n.T("✅ All messages reached a final state")
+	case m.timedOut:
+		status = i18n.T("⏰ Timed out before all messages reached a final state")
+	}
+
+	content := m.renderBar() + "\n\n" +
+		infoStyle.Render(bidiLine(i18n.Sprintf("✅ Delivered: %d   ❌ Failed: %d   ⏳ Pending: %d   📊 Total: %d",
+			m.summary.Delivered, m.summary.Failed, m.summary.Pending, m.summary.Total))) + "\n" +
+		infoStyle.Render(bidiLine(i18n.Sprintf("Last update: %s • %s", m.updatedAt.Format("15:04:05"), status)))
+
+	return boxStyle.Render(content)
+}
+
+// renderBar renders a progress bar split into delivered, failed and pending parts
+func (m WatchModel) renderBar() string {
+	barWidth := 40
+	if m.width > 20 && m.width-20 < barWidth {
+		barWidth = m.width - 20
+	}
+
+	delivered, failed := 0, 0
+	if m.summary.Total > 0 {
+		delivered = barWidth * m.summary.Delivered / m.summary.Total
+		failed = barWidth * m.summary.Failed / m.summary.Total
+	}
+	pending := barWidth - delivered - failed
+
+	deliveredStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4ADE80"))
+	failedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B"))
+	pendingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+
+	percent := 0
+	if m.summary.Total > 0 {
+		percent = 100 * (m.summary.Delivered + m.summary.Failed) / m.summary.Total
+	}
+
+	return "[" + deliveredStyle.Render(strings.Repeat("█", delivered)) +
+		failedStyle.Render(strings.Repeat("█", failed)) +
+		pendingStyle.Render(strings.Repeat("░", pending)) + "]" +
+		i18n.Sprintf(" %d%%", percent)
+}
+
+// renderInstructions renders instructions
+func (m WatchModel) renderInstructions() string {
+	instructionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Align(lipgloss.Center)
+
+	return instructionStyle.Render(bidiLine(i18n.T("Press q or Ctrl+C to stop watching")))
+}
+
+// Summary returns the latest delivery summary
+func (m WatchModel) Summary() api.DeliverySummary {
+	return m.summary
+}
+
+// Err returns the error that stopped the watch, if any
+func (m WatchModel) Err() error {
+	return m.err
+}
+
+// TimedOut reports whether the watch stopped because the timeout passed
+func (m WatchModel) TimedOut() bool {
+	return m.timedOut
+}
+
+// Cancelled reports whether the user stopped the watch
+func (m WatchModel) Cancelled() bool {
+	return m.quitting
+}
+
+// Messages
+type packReportMsg struct {
+	summary api.DeliverySummary
+}
+
+// loadPackReport loads the delivery report of a pack
+func loadPackReport(client *api.Client, packID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := client.GetPackReport(packID)
+		if err != nil {
+			return errMsg(err)
+		}
+		if !resp.IsSuccess() {
+			return errMsg(i18n.Errorf("API error: %s", resp.GetStatusMessage()))
+		}
+		return packReportMsg{summary: resp.Data.Summary()}
+	}
+}
+
+// NewWatchModel creates a new watch model
+func NewWatchModel(client *api.Client, packID string, poller *watch.Poller, timeout time.Duration) WatchModel {
+	return WatchModel{
+		client:   client,
+		packID:   packID,
+		poller:   poller,
+		deadline: time.Now().Add(timeout),
+	}
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/i18n"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderSteps renders the numbered steps of a wizard. Steps up to visited
+// are checked and can be jumped to by their number; the current one is highlighted.
+func renderSteps(steps []string, current, visited int) string {
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	currentStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#f7bd60")).
+		Bold(true)
+
+	progress := make([]string, len(steps))
+	for i, step := range steps {
+		mark := "○"
+		if i <= visited {
+			mark = "✓"
+		}
+		label := bidiLine(i18n.Sprintf("%d %s %s", i+1, mark, step))
+		if i == current {
+			progress[i] = currentStyle.Render(label)
+		} else {
+			progress[i] = mutedStyle.Render(label)
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Render(strings.Join(progress, mutedStyle.Render(" → ")))
+}
+
+// stepKey returns the step a number key jumps to, counting from 0. Persian
+// digits are accepted too, for keyboards left on the Persian layout.
+func stepKey(msg tea.KeyMsg, steps int) (int, bool) {
+	if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 {
+		return 0, false
+	}
+
+	r := msg.Runes[0]
+	var step int
+	switch {
+	case r >= '1' && r <= '9':
+		step = int(r - '1')
+	case r >= '۱' && r <= '۹':
+		step = int(r - '۱')
+	default:
+		return 0, false
+	}
+	return step, step < steps
+}
+
+// renderStepError renders the reason a wizard step could not be completed
+func renderStepError(err string) string {
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B"))
+
+	return errorStyle.Render(bidiLine("❌ " + err))
+}
+
+// renderQuitPrompt renders the question asked before unsent input is thrown away
+func renderQuitPrompt(question string) string {
+	promptStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B")).
+		Bold(true).
+		Align(lipgloss.Center)
+
+	return promptStyle.Render(bidiLine(question + " " + i18n.T("y: discard • n: keep editing")))
+}
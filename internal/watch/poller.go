@@ -0,0 +1,58 @@
+package watch
+
+import (
+	"time"
+
+	"github.com/SaneiyanReza/smsir-cli/internal/api"
+)
+
+const (
+	// DefaultMinInterval is the polling interval used while delivery reports keep changing
+	DefaultMinInterval = 2 * time.Second
+	// DefaultMaxInterval is the longest wait between polls when nothing changes
+	DefaultMaxInterval = 30 * time.Second
+	// backoffFactor is how much the interval grows after each unchanged poll
+	backoffFactor = 1.5
+)
+
+// Poller computes an adaptive polling interval: it polls quickly while
+// delivery reports keep arriving and backs off while nothing changes
+type Poller struct {
+	MinInterval time.Duration
+	MaxInterval time.Duration
+
+	interval time.Duration
+	last     api.DeliverySummary
+	seen     bool
+}
+
+// NewPoller creates a poller with the given interval bounds
+func NewPoller(minInterval, maxInterval time.Duration) *Poller {
+	if minInterval <= 0 {
+		minInterval = DefaultMinInterval
+	}
+	if maxInterval < minInterval {
+		maxInterval = minInterval
+	}
+	return &Poller{
+		MinInterval: minInterval,
+		MaxInterval: maxInterval,
+		interval:    minInterval,
+	}
+}
+
+// Next records the latest summary and returns how long to wait before the next poll
+func (p *Poller) Next(s api.DeliverySummary) time.Duration {
+	if p.seen && s == p.last {
+		p.interval = time.Duration(float64(p.interval) * backoffFactor)
+		if p.interval > p.MaxInterval {
+			p.interval = p.MaxInterval
+		}
+	} else {
+		p.interval = p.MinInterval
+	}
+
+	p.last = s
+	p.seen = true
+	return p.interval
+}